@@ -0,0 +1,215 @@
+// Package expect starts a type-safe predicate chain, where the arguments of
+// terminal predicates are checked at compile time against the type of the
+// value under test. Predicates are forwarded to the same underlying builder as
+// `verify.That()` and `require.That()`, producing identical failure output.
+package expect
+
+import (
+	"cmp"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/builder"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+)
+
+// Context captures an additional context value to be displayed upon failure.
+type Context = predicate.ContextValue
+
+// Value is a type-safe predicate builder for a value of type T.
+type Value[T any] struct {
+	t predicate.T
+	b *builder.Builder
+}
+
+// OrderedValue is a type-safe predicate builder for a value of an ordered type
+// T, supporting ordered comparison predicates.
+type OrderedValue[T cmp.Ordered] struct {
+	Value[T]
+}
+
+// SliceValue is a type-safe predicate builder for a slice value with elements
+// of type E, supporting sequence predicates.
+type SliceValue[E any] struct {
+	Value[[]E]
+}
+
+// That captures the test context and a value of any type for the purpose of
+// building and evaluating a test predicate through call chaining. The current
+// test will proceed even if the predicate fails.
+func That[T any](t predicate.T, v T, ctx ...Context) *Value[T] {
+	return &Value[T]{t, start(t, v, false, ctx)}
+}
+
+// Ordered is a variant of That() for values of ordered types.
+func Ordered[T cmp.Ordered](t predicate.T, v T, ctx ...Context) *OrderedValue[T] {
+	return &OrderedValue[T]{Value[T]{t, start(t, v, false, ctx)}}
+}
+
+// Slice is a variant of That() for slice values.
+func Slice[E any](t predicate.T, v []E, ctx ...Context) *SliceValue[E] {
+	return &SliceValue[E]{Value[[]E]{t, start(t, v, false, ctx)}}
+}
+
+// Require captures the test context and a value of any type for the purpose
+// of building and evaluating a test predicate through call chaining. The
+// current test will fail immediately if the predicate fails.
+func Require[T any](t predicate.T, v T, ctx ...Context) *Value[T] {
+	return &Value[T]{t, start(t, v, true, ctx)}
+}
+
+// RequireOrdered is a variant of Require() for values of ordered types.
+func RequireOrdered[T cmp.Ordered](t predicate.T, v T, ctx ...Context) *OrderedValue[T] {
+	return &OrderedValue[T]{Value[T]{t, start(t, v, true, ctx)}}
+}
+
+// RequireSlice is a variant of Require() for slice values.
+func RequireSlice[E any](t predicate.T, v []E, ctx ...Context) *SliceValue[E] {
+	return &SliceValue[E]{Value[[]E]{t, start(t, v, true, ctx)}}
+}
+
+func start(t predicate.T, v interface{}, required bool, ctx []Context) *builder.Builder {
+	var b = builder.New(t, v, required)
+	builder.CaptureCallsite(b, 2)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return b
+}
+
+func (v *Value[T]) helper() {
+	if v.t != nil {
+		v.t.Helper()
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Predicates on any value
+
+// Builder returns the underlying untyped builder, to continue the predicate
+// chain with predicates that are not available on the typed builder.
+func (v *Value[T]) Builder() *builder.Builder {
+	return v.b
+}
+
+// Eq tests if the value is equal to the specified value.
+func (v *Value[T]) Eq(rhs T) *predicate.Predicate {
+	v.helper()
+	return v.b.Eq(rhs)
+}
+
+// Ne tests if the value is different from the specified value.
+func (v *Value[T]) Ne(rhs T) *predicate.Predicate {
+	v.helper()
+	return v.b.Ne(rhs)
+}
+
+// IsOneOf tests if the value is equal to any of the specified values.
+func (v *Value[T]) IsOneOf(values ...T) *predicate.Predicate {
+	v.helper()
+	var args = make([]interface{}, len(values))
+	for i := range values {
+		args[i] = values[i]
+	}
+	return v.b.IsOneOf(args...)
+}
+
+// Passes evaluates a sub-expression predicate against the value.
+func (v *Value[T]) Passes(p *predicate.Predicate) *predicate.Predicate {
+	v.helper()
+	return v.b.Passes(p)
+}
+
+// Is is an extension point allowing for the definition of a custom predicate
+// function to evaluate the value.
+func (v *Value[T]) Is(desc string, f predicate.PredicateFunc) *predicate.Predicate {
+	v.helper()
+	return v.b.Is(desc, f)
+}
+
+// Predicates on any value
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Predicates on ordered values
+
+// Lt tests if the value is strictly less than a reference value.
+func (v *OrderedValue[T]) Lt(rhs T) *predicate.Predicate {
+	v.helper()
+	return v.b.Lt(rhs)
+}
+
+// Le tests if the value is less than or equal to a reference value.
+func (v *OrderedValue[T]) Le(rhs T) *predicate.Predicate {
+	v.helper()
+	return v.b.Le(rhs)
+}
+
+// Gt tests if the value is strictly greater than a reference value.
+func (v *OrderedValue[T]) Gt(rhs T) *predicate.Predicate {
+	v.helper()
+	return v.b.Gt(rhs)
+}
+
+// Ge tests if the value is greater than or equal to a reference value.
+func (v *OrderedValue[T]) Ge(rhs T) *predicate.Predicate {
+	v.helper()
+	return v.b.Ge(rhs)
+}
+
+// Predicates on ordered values
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Predicates and transformations on slice values
+
+// Length is a transformation that extracts the length of the slice for
+// further evaluation.
+func (v *SliceValue[E]) Length() *OrderedValue[int] {
+	v.b.Length()
+	return &OrderedValue[int]{Value[int]{v.t, v.b}}
+}
+
+// IsEmpty tests if the slice is empty.
+func (v *SliceValue[E]) IsEmpty() *predicate.Predicate {
+	v.helper()
+	return v.b.IsEmpty()
+}
+
+// IsNotEmpty tests if the slice is not empty.
+func (v *SliceValue[E]) IsNotEmpty() *predicate.Predicate {
+	v.helper()
+	return v.b.IsNotEmpty()
+}
+
+// Contains tests if the slice contains the given element.
+func (v *SliceValue[E]) Contains(e E) *predicate.Predicate {
+	v.helper()
+	return v.b.Contains([]E{e})
+}
+
+// StartsWith tests if the slice starts with the given sequence of elements.
+func (v *SliceValue[E]) StartsWith(rhs []E) *predicate.Predicate {
+	v.helper()
+	return v.b.StartsWith(rhs)
+}
+
+// EndsWith tests if the slice ends with the given sequence of elements.
+func (v *SliceValue[E]) EndsWith(rhs []E) *predicate.Predicate {
+	v.helper()
+	return v.b.EndsWith(rhs)
+}
+
+// All tests if all elements of the slice match the given predicate.
+func (v *SliceValue[E]) All(p *predicate.Predicate) *predicate.Predicate {
+	v.helper()
+	return v.b.All(p)
+}
+
+// Any tests if at least one element of the slice matches the given predicate.
+func (v *SliceValue[E]) Any(p *predicate.Predicate) *predicate.Predicate {
+	v.helper()
+	return v.b.Any(p)
+}
+
+// Predicates and transformations on slice values
+// ---------------------------------------------------------------------------
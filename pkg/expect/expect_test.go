@@ -0,0 +1,74 @@
+package expect_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/expect"
+	"github.com/maargenton/go-testpredicate/pkg/subexpr"
+	"github.com/maargenton/go-testpredicate/pkg/verify"
+)
+
+func TestExample(t *testing.T) {
+	expect.That(t, struct{ A int }{1}).Eq(struct{ A int }{1})
+	expect.That(t, "abc").Ne("abd")
+	expect.That(t, "abc").IsOneOf("abc", "def")
+	expect.That(t, 123).Passes(subexpr.Value().Lt(124))
+
+	expect.Ordered(t, 123).Lt(124)
+	expect.Ordered(t, 123).Le(123)
+	expect.Ordered(t, "abc").Gt("abb")
+	expect.Ordered(t, 1.5).Ge(1.5)
+	expect.Ordered(t, 123).Eq(123)
+
+	expect.Slice(t, []string{"a", "b", "c"}).Length().Eq(3)
+	expect.Slice(t, []string{"a", "b", "c"}).Length().Lt(5)
+	expect.Slice(t, []int{}).IsEmpty()
+	expect.Slice(t, []int{1, 2, 3}).IsNotEmpty()
+	expect.Slice(t, []int{1, 2, 3}).Contains(2)
+	expect.Slice(t, []int{1, 2, 3}).StartsWith([]int{1, 2})
+	expect.Slice(t, []int{1, 2, 3}).EndsWith([]int{2, 3})
+	expect.Slice(t, []int{1, 2, 3}).All(subexpr.Value().Lt(5))
+	expect.Slice(t, []int{1, 2, 3}).Any(subexpr.Value().Eq(2))
+
+	expect.Require(t, 123).Eq(123)
+	expect.RequireOrdered(t, 123).Lt(124)
+	expect.RequireSlice(t, []int{1, 2, 3}).Contains(3)
+
+	expect.That(t, "abc").Builder().Length().Eq(3)
+}
+
+func TestFailureOutputMatchesVerify(t *testing.T) {
+	var tt1, tt2 = &testContext{}, &testContext{}
+	verify.That(tt1, []int{1, 2, 3}).Length().Lt(3)
+	expect.Slice(tt2, []int{1, 2, 3}).Length().Lt(3)
+
+	if tt1.Output == "" || tt1.Output != tt2.Output {
+		t.Errorf("\noutput mismatch:\n%v\n%v", tt1.Output, tt2.Output)
+	}
+
+	var tt3 = &testContext{}
+	expect.Require(tt3, 123).Eq(124)
+	if !tt3.Failed {
+		t.Errorf("\nexpected required predicate to fail current test")
+	}
+}
+
+// ---------------------------------------------------------------------------
+
+type testContext struct {
+	Output string
+	Failed bool
+}
+
+func (c *testContext) Helper() {}
+
+func (c *testContext) Errorf(format string, args ...interface{}) {
+	c.Output += fmt.Sprintf(format, args...)
+}
+
+func (c *testContext) FailNow() {
+	c.Failed = true
+}
+
+func (c *testContext) Cleanup(f func()) {}
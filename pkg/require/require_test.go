@@ -1,7 +1,9 @@
 package require_test
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/require"
 )
@@ -13,3 +15,20 @@ func TestExample(t *testing.T) {
 		require.Context{Name: "double", Value: v * 2},
 	).ToString().Length().Eq(3)
 }
+
+func TestPolling(t *testing.T) {
+	var counter atomic.Int64
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(time.Millisecond)
+			counter.Add(1)
+		}
+	}()
+
+	require.Eventually(t, func() interface{} {
+		return counter.Load()
+	}, time.Second, time.Millisecond).Eq(3)
+	require.Consistently(t, func() interface{} {
+		return counter.Load()
+	}, 10*time.Millisecond, time.Millisecond).Eq(3)
+}
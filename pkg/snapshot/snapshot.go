@@ -0,0 +1,213 @@
+// Package snapshot defines a predicate that compares a value against a
+// snapshot (golden file) stored in the `testdata/__snapshots__` directory of
+// the package under test.
+//
+// Each snapshot is stored under `testdata/__snapshots__/<TestName>/<name>`.
+// Strings and byte slices are stored as is, while other values are stored
+// formatted through `prettyprint`. Missing snapshots are created on first
+// evaluation, and existing snapshots are rewritten instead of compared when
+// tests are run with `-update-snapshots`, with `UPDATE_SNAPSHOTS=1` in the
+// environment, or with an `-update` flag defined by the test package.
+//
+// Importing the package registers the `-update-snapshots` flag on the global
+// `flag.CommandLine` flag set; any other package registering a flag with the
+// same name in the same test binary causes a panic at initialization.
+//
+//	verify.That(t, output).Is(snapshot.Matches(t, "output.txt"))
+package snapshot
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+)
+
+// T is the subset of `testing.TB` required to locate and manage the snapshots
+// of the current test.
+type T interface {
+	Name() string
+	Logf(format string, args ...interface{})
+	Cleanup(f func())
+}
+
+// Dir is the directory, relative to the package under test, where all the
+// snapshots are stored.
+const Dir = "testdata/__snapshots__"
+
+// updateFlag is registered at import time, so that it is parsed along with the
+// `testing` flags; see the package documentation for possible conflicts.
+var updateFlag = flag.Bool("update-snapshots", false,
+	"rewrite the snapshots compared with `snapshot.Matches()`")
+
+// Updating returns true if snapshots should be rewritten instead of being
+// compared.
+func Updating() bool {
+	if *updateFlag {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if v, err := strconv.ParseBool(f.Value.String()); err == nil && v {
+			return true
+		}
+	}
+	v, err := strconv.ParseBool(os.Getenv("UPDATE_SNAPSHOTS"))
+	return err == nil && v
+}
+
+// Path returns the path of the named snapshot for the current test.
+func Path(t T, name string) string {
+	return filepath.Join(testDir(t), name)
+}
+
+// Matches tests if a value matches the named snapshot for the current test,
+// for use with `.Is()`. The snapshot is created if it does not exist yet, and
+// rewritten when snapshots are being updated. Snapshot names must not be empty
+// or contain path separators or `..`, so that snapshots are always stored
+// within the directory of the current test.
+func Matches(t T, name string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} matches snapshot '%v'", name)
+	if err := validateName(name); err != nil {
+		return desc, func(v interface{}) (bool, []predicate.ContextValue, error) {
+			return false, nil, err
+		}
+	}
+	var path = Path(t, name)
+	track(t, name)
+
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		var content = formatSnapshot(v)
+		ctx = []predicate.ContextValue{
+			{Name: "snapshot", Value: path, Pre: true},
+		}
+
+		expected, err := os.ReadFile(path)
+		if Updating() || errors.Is(err, fs.ErrNotExist) {
+			if err = writeSnapshot(path, content); err != nil {
+				return false, ctx, err
+			}
+			t.Logf("snapshot '%v' written", path)
+			return true, nil, nil
+		}
+		if err != nil {
+			return false, ctx, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+
+		if string(expected) == content {
+			return true, nil, nil
+		}
+		ctx = append(ctx, predicate.ContextValue{
+			Name: "diff", Value: diff.Unified(content, string(expected)), Pre: true,
+		})
+		return false, ctx, nil
+	}
+	return
+}
+
+// Obsolete returns the names of the snapshots stored for the current test that
+// have not been used by `Matches()` so far. Snapshots of sub-tests are stored
+// in sub-directories and are not included.
+func Obsolete(t T) []string {
+	var entries, err = os.ReadDir(testDir(t))
+	if err != nil {
+		return nil
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	var used = registry.tests[t.Name()]
+
+	var obsolete []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && !used[e.Name()] {
+			obsolete = append(obsolete, e.Name())
+		}
+	}
+	sort.Strings(obsolete)
+	return obsolete
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions
+
+var registry = struct {
+	mu    sync.Mutex
+	tests map[string]map[string]bool
+}{
+	tests: map[string]map[string]bool{},
+}
+
+// track records the use of a snapshot by the current test. Upon completion of
+// the test, obsolete snapshots are reported, or removed when snapshots are
+// being updated.
+func track(t T, name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	var used, ok = registry.tests[t.Name()]
+	if !ok {
+		used = map[string]bool{}
+		registry.tests[t.Name()] = used
+		t.Cleanup(func() {
+			for _, name := range Obsolete(t) {
+				var path = Path(t, name)
+				if Updating() {
+					os.Remove(path)
+					t.Logf("obsolete snapshot '%v' removed", path)
+				} else {
+					t.Logf("obsolete snapshot '%v'", path)
+				}
+			}
+			registry.mu.Lock()
+			delete(registry.tests, t.Name())
+			registry.mu.Unlock()
+		})
+	}
+	used[name] = true
+}
+
+func validateName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf(
+			"invalid snapshot name '%v', must not be empty or contain path separators or '..'",
+			name)
+	}
+	return nil
+}
+
+func testDir(t T) string {
+	return filepath.Join(Dir, filepath.FromSlash(t.Name()))
+}
+
+func formatSnapshot(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return prettyprint.FormatValue(v) + "\n"
+}
+
+func writeSnapshot(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Helper functions
+// ---------------------------------------------------------------------------
@@ -0,0 +1,126 @@
+package snapshot_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/snapshot"
+	"github.com/maargenton/go-testpredicate/pkg/verify"
+)
+
+func TestMatchesSnapshot(t *testing.T) {
+	verify.That(t, "line 1\nline 2\n").Is(snapshot.Matches(t, "output.txt"))
+	verify.That(t, map[string]int{"a": 1}).Is(snapshot.Matches(t, "value"))
+}
+
+func TestMatchesSnapshotMismatch(t *testing.T) {
+	var tt = &testContext{name: "TestSnapshotMismatchScratch"}
+	var dir = filepath.Join(snapshot.Dir, tt.name)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "output.txt"), []byte("line 1\nline 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, f := snapshot.Matches(tt, "output.txt")
+	r, ctx, err := f("line 1\nline 3\n")
+	if r || err != nil {
+		t.Fatalf("\nexpected snapshot mismatch, got %v, %v", r, err)
+	}
+	if len(ctx) != 2 || ctx[1].Name != "diff" ||
+		!strings.Contains(ctx[1].Value.(string), "+line 3") {
+
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+	tt.runCleanup()
+	if tt.output != "" {
+		t.Errorf("\nunexpected output from cleanup:\n%v", tt.output)
+	}
+}
+
+func TestMissingAndObsoleteSnapshots(t *testing.T) {
+	var tt = &testContext{name: "TestSnapshotScratch"}
+	var dir = filepath.Join(snapshot.Dir, tt.name)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "old"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, f := snapshot.Matches(tt, "new")
+	if r, _, err := f(123); !r || err != nil {
+		t.Fatalf("\nexpected missing snapshot to be created, got %v, %v", r, err)
+	}
+	content, err := os.ReadFile(snapshot.Path(tt, "new"))
+	if err != nil || string(content) != "123\n" {
+		t.Errorf("\nunexpected snapshot content: %q, %v", content, err)
+	}
+
+	if obsolete := snapshot.Obsolete(tt); !reflect.DeepEqual(obsolete, []string{"old"}) {
+		t.Errorf("\nunexpected obsolete snapshots: %v", obsolete)
+	}
+	tt.runCleanup()
+	if !strings.Contains(tt.output, "obsolete snapshot") {
+		t.Errorf("\nobsolete snapshot not reported:\n%v", tt.output)
+	}
+}
+
+func TestInvalidSnapshotNames(t *testing.T) {
+	var tt = &testContext{name: "TestInvalidSnapshotNamesScratch"}
+	for _, name := range []string{"", "../x", "a/b", `a\b`, ".."} {
+		_, f := snapshot.Matches(tt, name)
+		r, _, err := f("content")
+		var expected = fmt.Sprintf("invalid snapshot name '%v', "+
+			"must not be empty or contain path separators or '..'", name)
+		if r || err == nil || err.Error() != expected {
+			t.Errorf("\nunexpected result for snapshot name %q: %v, %v", name, r, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(snapshot.Dir, tt.name)); err == nil {
+		t.Errorf("\nunexpected snapshot directory created for invalid names")
+	}
+	if len(tt.cleanupFuncs) != 0 {
+		t.Errorf("\ninvalid snapshot names should not be tracked")
+	}
+}
+
+func TestUpdating(t *testing.T) {
+	t.Setenv("UPDATE_SNAPSHOTS", "1")
+	if !snapshot.Updating() {
+		t.Errorf("\nexpected snapshots to be updated")
+	}
+}
+
+// ---------------------------------------------------------------------------
+
+type testContext struct {
+	name         string
+	output       string
+	cleanupFuncs []func()
+}
+
+func (c *testContext) Name() string { return c.name }
+
+func (c *testContext) Logf(format string, args ...interface{}) {
+	c.output += fmt.Sprintf(format, args...) + "\n"
+}
+
+func (c *testContext) Cleanup(f func()) {
+	c.cleanupFuncs = append(c.cleanupFuncs, f)
+}
+
+func (c *testContext) runCleanup() {
+	for i := len(c.cleanupFuncs) - 1; i >= 0; i-- {
+		c.cleanupFuncs[i]()
+	}
+}
@@ -10,6 +10,7 @@ package builder
 import (
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 )
@@ -24,8 +25,36 @@ type Builder struct {
 	line     int
 	p        predicate.Predicate
 	required bool
+	poll     *polling
 
-	Ctx []predicate.ContextValue
+	Ctx   []predicate.ContextValue
+	Clock Clock
+}
+
+// Clock abstracts the passage of time for the evaluation of polling
+// predicates, allowing them to be tested deterministically.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// systemClock is the default Clock, backed by the `time` package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// MinPollingInterval is the shortest interval between two evaluations of a
+// polling predicate, preventing a zero or negative interval from spinning.
+var MinPollingInterval = time.Millisecond
+
+// polling captures the configuration of a builder whose predicate is
+// evaluated repeatedly on freshly sampled values.
+type polling struct {
+	sample       func() interface{}
+	timeout      time.Duration
+	interval     time.Duration
+	consistently bool
 }
 
 // New returns a new predicate builder capture the given test context, value and
@@ -47,6 +76,50 @@ func CaptureCallsite(b *Builder, skip int) {
 	}
 }
 
+// RegisterTransformation appends a transformation to the predicate chain
+// captured by the builder. It is intended for use by custom builders generated
+// with `forward_api`.
+func RegisterTransformation(b *Builder, desc string, f predicate.TransformFunc) {
+	b.p.RegisterTransformation(desc, f)
+}
+
+// RegisterPredicate sets the final predicate of the chain captured by the
+// builder and evaluates it if the builder has a test context. It is intended
+// for use by custom builders generated with `forward_api`.
+func RegisterPredicate(b *Builder, desc string, f predicate.PredicateFunc) *predicate.Predicate {
+	b.p.RegisterPredicate(desc, f)
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// Eventually configures the builder to repeatedly evaluate its predicate on
+// values returned by `sample`, every `interval`, until it passes or until
+// `timeout` has elapsed. Intervals shorter than `MinPollingInterval` are
+// raised to that minimum.
+func Eventually(b *Builder, sample func() interface{}, timeout, interval time.Duration) {
+	b.poll = &polling{
+		sample:   sample,
+		timeout:  timeout,
+		interval: interval,
+	}
+}
+
+// Consistently configures the builder to repeatedly evaluate its predicate on
+// values returned by `sample`, every `interval`, and to fail as soon as one
+// evaluation fails before `timeout` has elapsed. Intervals shorter than
+// `MinPollingInterval` are raised to that minimum.
+func Consistently(b *Builder, sample func() interface{}, timeout, interval time.Duration) {
+	b.poll = &polling{
+		sample:       sample,
+		timeout:      timeout,
+		interval:     interval,
+		consistently: true,
+	}
+}
+
 // Evaluate is used to evaluate the predicate on the value and test context
 // captured by the builder. Note that if no test context is set, no evaluation
 // is performed.
@@ -56,7 +129,13 @@ func Evaluate(b *Builder) {
 	}
 	b.t.Helper()
 
-	success, ctx := b.p.Evaluate(b.value)
+	var success bool
+	var ctx []predicate.ContextValue
+	if b.poll != nil {
+		success, ctx = evaluatePolling(b)
+	} else {
+		success, ctx = b.p.Evaluate(b.value)
+	}
 	if !success {
 		ctx = append(ctx, b.Ctx...)
 		b.t.Errorf("\n%v", predicate.FormatContextValues(ctx))
@@ -83,3 +162,43 @@ func VerifyCompletness(b *Builder) {
 		b.t.Errorf("\n%vpredicate chain does not evaluate anything", prefix)
 	}
 }
+
+// evaluatePolling evaluates the predicate repeatedly on sampled values, as
+// configured by `Eventually()` or `Consistently()`, and returns the outcome
+// with the context of the last evaluation.
+func evaluatePolling(b *Builder) (success bool, ctx []predicate.ContextValue) {
+	var clock = b.Clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	var interval = max(b.poll.interval, MinPollingInterval)
+	var start = clock.Now()
+	var attempts = 0
+	var elapsed time.Duration
+	for {
+		attempts++
+		success, ctx = b.p.Evaluate(b.poll.sample())
+		elapsed = clock.Now().Sub(start)
+		if success != b.poll.consistently || elapsed >= b.poll.timeout {
+			break
+		}
+		clock.Sleep(min(interval, b.poll.timeout-elapsed))
+	}
+
+	var qualifier = "eventually"
+	if b.poll.consistently {
+		qualifier = "consistently"
+	}
+	for i := range ctx {
+		if ctx[i].Name == "expected" {
+			ctx[i].Value = fmt.Sprintf("%v within %v, %v",
+				qualifier, b.poll.timeout, ctx[i].Value)
+		}
+	}
+	ctx = append(ctx,
+		predicate.ContextValue{Name: "attempts", Value: attempts, Pre: true},
+		predicate.ContextValue{Name: "elapsed", Value: elapsed, Pre: true},
+	)
+	return
+}
@@ -4,11 +4,80 @@ package builder
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 )
 
+// ---------------------------------------------------------------------------
+// From pkg/utils/predicate/impl/aggregate.go
+
+// Sum is a transformation that computes the sum of the numeric values of a
+// collection, or of the values of a map, for further evaluation. The result is
+// an int64 if all values are signed integers, an uint64 if all values are
+// unsigned integers, a time.Duration if all values are durations, and a float64
+// otherwise. Integer and duration sums that overflow fail with an error.
+func (b *Builder) Sum() *Builder {
+	b.p.RegisterTransformation(impl.Sum())
+	return b
+}
+
+// Min is a transformation that extracts the smallest value of a non-empty
+// collection, or of the values of a map, for further evaluation. All values
+// must be order comparable with each other.
+func (b *Builder) Min() *Builder {
+	b.p.RegisterTransformation(impl.Min())
+	return b
+}
+
+// Max is a transformation that extracts the largest value of a non-empty
+// collection, or of the values of a map, for further evaluation. All values
+// must be order comparable with each other.
+func (b *Builder) Max() *Builder {
+	b.p.RegisterTransformation(impl.Max())
+	return b
+}
+
+// Mean is a transformation that computes the arithmetic mean of the numeric
+// values of a non-empty collection, or of the values of a map, for further
+// evaluation. The result is a time.Duration if all values are durations, and a
+// float64 otherwise.
+func (b *Builder) Mean() *Builder {
+	b.p.RegisterTransformation(impl.Mean())
+	return b
+}
+
+// StdDev is a transformation that computes the population standard deviation
+// of the numeric values of a non-empty collection, or of the values of a map,
+// for further evaluation. The result is a time.Duration if all values are
+// durations, and a float64 otherwise.
+func (b *Builder) StdDev() *Builder {
+	b.p.RegisterTransformation(impl.StdDev())
+	return b
+}
+
+// Percentile is a transformation that computes the p-th percentile (0 to 100)
+// of the numeric values of a non-empty collection, or of the values of a map,
+// for further evaluation, interpolating linearly between the closest ranks.
+// The result is a time.Duration if all values are durations, and a float64
+// otherwise.
+func (b *Builder) Percentile(p float64) *Builder {
+	b.p.RegisterTransformation(impl.Percentile(p))
+	return b
+}
+
+// CountWhere is a transformation that counts the values of a collection that
+// match the given predicate, for further evaluation.
+func (b *Builder) CountWhere(p *predicate.Predicate) *Builder {
+	b.p.RegisterTransformation(impl.CountWhere(p))
+	return b
+}
+
+// From pkg/utils/predicate/impl/aggregate.go
+// ---------------------------------------------------------------------------
+
 // ---------------------------------------------------------------------------
 // From pkg/utils/predicate/impl/collection.go
 
@@ -32,6 +101,200 @@ func (b *Builder) Any(p *predicate.Predicate) *predicate.Predicate {
 	return &b.p
 }
 
+// Collect is a transformation that materializes the elements of an iterable
+// value into a slice for further evaluation. An `iter.Seq[T]` and a channel of
+// T produce a `[]T`, while an `iter.Seq2` and a map produce a
+// `[]value.KeyValue`, with map entries ordered by key. Channels are drained
+// until closed, or until no new element is received within
+// `value.ChannelTimeout`.
+func (b *Builder) Collect() *Builder {
+	b.p.RegisterTransformation(impl.Collect())
+	return b
+}
+
+// None tests if no value of a collection matches the given predicate
+func (b *Builder) None(p *predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.None(p))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// Exactly tests if exactly n values of a collection match the given predicate
+func (b *Builder) Exactly(n int, p *predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.Exactly(n, p))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// AtLeast tests if at least n values of a collection match the given predicate
+func (b *Builder) AtLeast(n int, p *predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.AtLeast(n, p))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// AtMost tests if at most n values of a collection match the given predicate
+func (b *Builder) AtMost(n int, p *predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.AtMost(n, p))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsSorted tests if the values of a collection are in ascending order, allowing
+// for consecutive equal values.
+func (b *Builder) IsSorted() *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsSorted())
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsSortedBy tests if the values of a collection are in ascending order of the
+// value identified by `keypath`, allowing for consecutive equal keys.
+func (b *Builder) IsSortedBy(keypath string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsSortedBy(keypath))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsStrictlyIncreasing tests if the values of a collection are in strictly
+// ascending order, without consecutive equal values.
+func (b *Builder) IsStrictlyIncreasing() *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsStrictlyIncreasing())
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsDescending tests if the values of a collection are in descending order,
+// allowing for consecutive equal values.
+func (b *Builder) IsDescending() *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsDescending())
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// HasNoDuplicates tests if all the values of a collection are distinct.
+func (b *Builder) HasNoDuplicates() *predicate.Predicate {
+	b.p.RegisterPredicate(impl.HasNoDuplicates())
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// HasNoDuplicatesBy tests if the values identified by `keypath` are distinct
+// for all the values of a collection.
+func (b *Builder) HasNoDuplicatesBy(keypath string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.HasNoDuplicatesBy(keypath))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// Where is a transformation that filters the values of a collection, keeping
+// only the ones that match the given predicate, for further evaluation.
+func (b *Builder) Where(p *predicate.Predicate) *Builder {
+	b.p.RegisterTransformation(impl.Where(p))
+	return b
+}
+
+// Select is a transformation that extracts the value identified by `keypath`
+// from each value of a collection, for further evaluation. See value.Field()
+// for more details about keypaths.
+func (b *Builder) Select(keypath string) *Builder {
+	b.p.RegisterTransformation(impl.Select(keypath))
+	return b
+}
+
+// Sorted is a transformation that sorts the values of a collection in
+// ascending order, for further evaluation. All values must be order comparable
+// with each other.
+func (b *Builder) Sorted() *Builder {
+	b.p.RegisterTransformation(impl.Sorted())
+	return b
+}
+
+// SortBy is a transformation that sorts the values of a collection in
+// ascending order of the value identified by `keypath`, for further
+// evaluation. The sort is stable, preserving the relative order of values with
+// equal keys.
+func (b *Builder) SortBy(keypath string) *Builder {
+	b.p.RegisterTransformation(impl.SortBy(keypath))
+	return b
+}
+
+// Reverse is a transformation that reverses the order of the values of a
+// collection, for further evaluation.
+func (b *Builder) Reverse() *Builder {
+	b.p.RegisterTransformation(impl.Reverse())
+	return b
+}
+
+// GroupBy is a transformation that groups the values of a collection by the
+// value identified by `keypath`, for further evaluation. The result is a map
+// from each distinct key to the slice of values sharing that key, in their
+// original order.
+func (b *Builder) GroupBy(keypath string) *Builder {
+	b.p.RegisterTransformation(impl.GroupBy(keypath))
+	return b
+}
+
+// Flatten is a transformation that concatenates the nested collections of a
+// collection of collections, for further evaluation. Only one level of nesting
+// is removed, and values that are not collections are kept as is.
+func (b *Builder) Flatten() *Builder {
+	b.p.RegisterTransformation(impl.Flatten())
+	return b
+}
+
+// First is a transformation that extracts the first value of a non-empty
+// collection for further evaluation.
+func (b *Builder) First() *Builder {
+	b.p.RegisterTransformation(impl.First())
+	return b
+}
+
+// Last is a transformation that extracts the last value of a non-empty
+// collection for further evaluation.
+func (b *Builder) Last() *Builder {
+	b.p.RegisterTransformation(impl.Last())
+	return b
+}
+
+// At is a transformation that extracts the value at index `i` of a collection
+// for further evaluation. Negative indexes count from the end of the
+// collection.
+func (b *Builder) At(i int) *Builder {
+	b.p.RegisterTransformation(impl.At(i))
+	return b
+}
+
 // From pkg/utils/predicate/impl/collection.go
 // ---------------------------------------------------------------------------
 
@@ -89,6 +352,19 @@ func (b *Builder) IsEqualTo(rhs interface{}) *predicate.Predicate {
 	return &b.p
 }
 
+// EqWith tests if a value is equal to the specified value, according to
+// `value.DeepEqual()` with the given options. Unlike `Eq()`, the comparison
+// honors comparers registered with `value.RegisterComparer()` and `Equal(T)
+// bool` methods at every level of the values.
+func (b *Builder) EqWith(rhs interface{}, opts ...value.EqualOption) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.EqWith(rhs, opts...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
 // IsNotEqualTo tests if a value is equatable but different from the specified
 // value.
 func (b *Builder) IsNotEqualTo(rhs interface{}) *predicate.Predicate {
@@ -148,6 +424,16 @@ func (b *Builder) AsError(target interface{}) *Builder {
 	return b
 }
 
+// ErrorChain is a transformation predicate that flattens the unwrap tree of
+// an error into a slice of errors, starting with the error itself, followed
+// depth-first by the errors it wraps through either `Unwrap() error` or
+// `Unwrap() []error`, as produced by `errors.Join()`. A nil error yields an
+// empty slice.
+func (b *Builder) ErrorChain() *Builder {
+	b.p.RegisterTransformation(impl.ErrorChain())
+	return b
+}
+
 // From pkg/utils/predicate/impl/error.go
 // ---------------------------------------------------------------------------
 
@@ -191,6 +477,106 @@ func (b *Builder) Passes(p *predicate.Predicate) *predicate.Predicate {
 // From pkg/utils/predicate/impl/impl.go
 // ---------------------------------------------------------------------------
 
+// ---------------------------------------------------------------------------
+// From pkg/utils/predicate/impl/json.go
+
+// IsJSONEqual tests if a value is a JSON document semantically equivalent to
+// the expected one, ignoring key order in objects and comparing numbers
+// numerically. Both the value and the expected document can be provided as a
+// string, a byte slice or an io.Reader containing JSON, or as any other value
+// that gets marshaled to JSON.
+func (b *Builder) IsJSONEqual(expected interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsJSONEqual(expected))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// ParseJSON is a transformation that decodes a JSON document, provided as a
+// string, a byte slice or an io.Reader, into a tree of `map[string]any`,
+// `[]any`, `string`, `float64`, `bool` and `nil` values for further
+// evaluation. Integers too large to be represented exactly as float64 are kept
+// as `json.Number`.
+func (b *Builder) ParseJSON() *Builder {
+	b.p.RegisterTransformation(impl.ParseJSON())
+	return b
+}
+
+// JSONPath is a transformation that decodes a JSON document, if not already
+// decoded, and extracts the value identified by `path` for further
+// evaluation. The supported path syntax includes the root `$`, child names as
+// `.name` or `["name"]`, array indexes as `[i]`, where negative indexes count
+// from the end, and `[*]` wildcards that collect the matching values of all
+// children into an array.
+func (b *Builder) JSONPath(path string) *Builder {
+	b.p.RegisterTransformation(impl.JSONPath(path))
+	return b
+}
+
+// From pkg/utils/predicate/impl/json.go
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// From pkg/utils/predicate/impl/logical.go
+
+// Not tests that a value does not match the given sub-expression predicate.
+func (b *Builder) Not(p *predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.Not(p))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// AllOf tests that a value matches all of the given sub-expression predicates.
+// All predicates are evaluated and each failing one is reported.
+func (b *Builder) AllOf(ps ...*predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.AllOf(ps...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// AnyOf tests that a value matches at least one of the given sub-expression
+// predicates. Upon failure, each failing predicate is reported.
+func (b *Builder) AnyOf(ps ...*predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.AnyOf(ps...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// NoneOf tests that a value matches none of the given sub-expression
+// predicates. All predicates are evaluated and each passing one is reported.
+func (b *Builder) NoneOf(ps ...*predicate.Predicate) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.NoneOf(ps...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsOneOf tests if a value is equal to any of the specified values.
+func (b *Builder) IsOneOf(values ...interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsOneOf(values...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// From pkg/utils/predicate/impl/logical.go
+// ---------------------------------------------------------------------------
+
 // ---------------------------------------------------------------------------
 // From pkg/utils/predicate/impl/map.go
 
@@ -210,6 +596,56 @@ func (b *Builder) MapValues() *Builder {
 	return b
 }
 
+// Lookup is a transformation predicate that applies only to map values and
+// extracts the value associated with the given key for further evaluation.
+// Unlike `Field()`, it fails with an error if the key is missing.
+func (b *Builder) Lookup(k interface{}) *Builder {
+	b.p.RegisterTransformation(impl.Lookup(k))
+	return b
+}
+
+// HasKey tests if a map contains the given key.
+func (b *Builder) HasKey(k interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.HasKey(k))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// HasKeys tests if a map contains all of the given keys.
+func (b *Builder) HasKeys(ks ...interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.HasKeys(ks...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// HasEntry tests if a map contains the given key, associated with a value
+// equal to the given value.
+func (b *Builder) HasEntry(k, e interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.HasEntry(k, e))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// ContainsEntries tests if a map contains all the entries of the given map,
+// with equal values, independently of any other entry.
+func (b *Builder) ContainsEntries(entries interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.ContainsEntries(entries))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
 // From pkg/utils/predicate/impl/map.go
 // ---------------------------------------------------------------------------
 
@@ -256,7 +692,10 @@ func (b *Builder) IsGreaterOrEqualTo(rhs interface{}) *predicate.Predicate {
 	return &b.p
 }
 
-// IsCloseTo tests if a value is within tolerance of a reference value
+// IsCloseTo tests if a value is within tolerance of a reference value. Both
+// values can be numbers, complex numbers, or sequences, maps and structs of
+// matching shape, compared component by component; see value.MaxDifference()
+// for more details.
 func (b *Builder) IsCloseTo(rhs interface{}, tolerance float64) *predicate.Predicate {
 	b.p.RegisterPredicate(impl.IsCloseTo(rhs, tolerance))
 	if b.t != nil {
@@ -266,6 +705,107 @@ func (b *Builder) IsCloseTo(rhs interface{}, tolerance float64) *predicate.Predi
 	return &b.p
 }
 
+// IsCloseToRel tests if a value is within a relative tolerance of a reference
+// value, i.e. if `|v - rhs| <= rel * max(|v|, |rhs|)` for every component.
+func (b *Builder) IsCloseToRel(rhs interface{}, rel float64) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsCloseToRel(rhs, rel))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsWithinULPs tests if a floating point value is within `n` units in the last
+// place of a reference value, i.e. if there are at most `n` representable
+// floating point values between them, for every component.
+func (b *Builder) IsWithinULPs(rhs interface{}, n int) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsWithinULPs(rhs, n))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsBefore tests if a value is strictly before a reference value. Times are
+// compared as instants, ignoring location and monotonic clock reading.
+func (b *Builder) IsBefore(rhs interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsBefore(rhs))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsAfter tests if a value is strictly after a reference value. Times are
+// compared as instants, ignoring location and monotonic clock reading.
+func (b *Builder) IsAfter(rhs interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsAfter(rhs))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsBetween tests if a value is between two reference values, with both
+// bounds inclusive.
+func (b *Builder) IsBetween(lo, hi interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsBetween(lo, hi))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsStrictlyBetween tests if a value is between two reference values, with
+// both bounds exclusive.
+func (b *Builder) IsStrictlyBetween(lo, hi interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsStrictlyBetween(lo, hi))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsInRange tests if a value is within the half-open range defined by two
+// reference values, with the lower bound inclusive and the upper bound
+// exclusive.
+func (b *Builder) IsInRange(lo, hi interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsInRange(lo, hi))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsCloseToTime tests if a time value is within a given duration of a
+// reference time.
+func (b *Builder) IsCloseToTime(rhs time.Time, tolerance time.Duration) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsCloseToTime(rhs, tolerance))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsWithin tests if a time value is within a given duration of the current
+// time, as sampled when the predicate is evaluated.
+func (b *Builder) IsWithin(tolerance time.Duration) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsWithin(tolerance))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
 // Lt tests if a value is strictly less than a reference value
 func (b *Builder) Lt(rhs interface{}) *predicate.Predicate {
 	b.p.RegisterPredicate(impl.Lt(rhs))
@@ -340,7 +880,7 @@ func (b *Builder) PanicsAndRecoveredValue() *Builder {
 
 // Length is a transformation predicate that extract the length of a value for
 // further evaluation. It applies to values of type String, Array, Slice, Map,
-// and Channel.
+// and Channel, and to iterators, which are collected first.
 func (b *Builder) Length() *Builder {
 	b.p.RegisterTransformation(impl.Length())
 	return b
@@ -354,7 +894,7 @@ func (b *Builder) Capacity() *Builder {
 	return b
 }
 
-// IsEmpty tests if a sequence or container is empty.
+// IsEmpty tests if a sequence, container or iterator is empty.
 func (b *Builder) IsEmpty() *predicate.Predicate {
 	b.p.RegisterPredicate(impl.IsEmpty())
 	if b.t != nil {
@@ -364,7 +904,7 @@ func (b *Builder) IsEmpty() *predicate.Predicate {
 	return &b.p
 }
 
-// IsNotEmpty tests if a sequence or container is not empty.
+// IsNotEmpty tests if a sequence, container or iterator is not empty.
 func (b *Builder) IsNotEmpty() *predicate.Predicate {
 	b.p.RegisterPredicate(impl.IsNotEmpty())
 	if b.t != nil {
@@ -375,7 +915,8 @@ func (b *Builder) IsNotEmpty() *predicate.Predicate {
 }
 
 // StartsWith tests if a sequence value starts with the given sequence, and can
-// be applied to  strings, arrays and slices.
+// be applied to  strings, arrays and slices, as well as iterators, maps and
+// channels, which are collected first.
 func (b *Builder) StartsWith(rhs interface{}) *predicate.Predicate {
 	b.p.RegisterPredicate(impl.StartsWith(rhs))
 	if b.t != nil {
@@ -386,7 +927,8 @@ func (b *Builder) StartsWith(rhs interface{}) *predicate.Predicate {
 }
 
 // Contains tests if a sequence value contains the given sequence, and can
-// be applied to  strings, arrays and slices.
+// be applied to  strings, arrays and slices, as well as iterators, maps and
+// channels, which are collected first.
 func (b *Builder) Contains(rhs interface{}) *predicate.Predicate {
 	b.p.RegisterPredicate(impl.Contains(rhs))
 	if b.t != nil {
@@ -397,7 +939,8 @@ func (b *Builder) Contains(rhs interface{}) *predicate.Predicate {
 }
 
 // EndsWith tests if a sequence value ends with the given sequence, and can
-// be applied to  strings, arrays and slices.
+// be applied to  strings, arrays and slices, as well as iterators, maps and
+// channels, which are collected first.
 func (b *Builder) EndsWith(rhs interface{}) *predicate.Predicate {
 	b.p.RegisterPredicate(impl.EndsWith(rhs))
 	if b.t != nil {
@@ -478,14 +1021,38 @@ func (b *Builder) IsSupersetOf(rhs interface{}) *predicate.Predicate {
 	return &b.p
 }
 
+// IsPermutationOf tests if two containers contain the same elements with the
+// same multiplicity, independently of order. Elements that are not comparable
+// (e.g. slices or structs containing slices) are matched pairwise.
+func (b *Builder) IsPermutationOf(rhs interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsPermutationOf(rhs))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// ElementsMatch tests if two containers contain the same elements with the
+// same multiplicity, independently of order.
+func (b *Builder) ElementsMatch(rhs interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.ElementsMatch(rhs))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
 // From pkg/utils/predicate/impl/set.go
 // ---------------------------------------------------------------------------
 
 // ---------------------------------------------------------------------------
 // From pkg/utils/predicate/impl/string.go
 
-// Matches tests if a string matches a regular expression
-func (b *Builder) Matches(re string) *predicate.Predicate {
+// Matches tests if a string matches a regular expression, specified either as
+// a string or as a pre-compiled `*regexp.Regexp`.
+func (b *Builder) Matches(re any) *predicate.Predicate {
 	b.p.RegisterPredicate(impl.Matches(re))
 	if b.t != nil {
 		b.t.Helper()
@@ -494,6 +1061,52 @@ func (b *Builder) Matches(re string) *predicate.Predicate {
 	return &b.p
 }
 
+// EqualFold tests if a string is equal to the specified string under Unicode
+// case-folding.
+func (b *Builder) EqualFold(rhs string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.EqualFold(rhs))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsEqualIgnoringWhitespace tests if a string is equal to the specified string
+// once all whitespace characters are removed from both.
+func (b *Builder) IsEqualIgnoringWhitespace(rhs string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsEqualIgnoringWhitespace(rhs))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// ContainsLine tests if a multi-line string contains a line that is exactly
+// equal to the specified string, regardless of the newline convention.
+func (b *Builder) ContainsLine(line string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.ContainsLine(line))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// MatchesGlob tests if a string matches a glob pattern, where `*` matches any
+// sequence of characters including newlines and path separators, `?` matches
+// any single character, `[...]` matches a character class, negated with a
+// leading `!` or `^`, and `\` escapes the next character.
+func (b *Builder) MatchesGlob(pattern string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.MatchesGlob(pattern))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
 // ToString is a transformation predicate that converts any value to a string
 // representation using `%v` formatting option.
 func (b *Builder) ToString() *Builder {
@@ -515,6 +1128,93 @@ func (b *Builder) ToUpper() *Builder {
 	return b
 }
 
+// TrimSpace is a transformation predicate that removes all leading and
+// trailing whitespace from a string.
+func (b *Builder) TrimSpace() *Builder {
+	b.p.RegisterTransformation(impl.TrimSpace())
+	return b
+}
+
+// NormalizeNewlines is a transformation predicate that converts all Windows
+// (`\r\n`) and classic Mac (`\r`) newlines of a string into `\n`.
+func (b *Builder) NormalizeNewlines() *Builder {
+	b.p.RegisterTransformation(impl.NormalizeNewlines())
+	return b
+}
+
+// NormalizeWhitespace is a transformation predicate that trims a string and
+// collapses every sequence of whitespace characters, including newlines, into
+// a single space.
+func (b *Builder) NormalizeWhitespace() *Builder {
+	b.p.RegisterTransformation(impl.NormalizeWhitespace())
+	return b
+}
+
+// Dedent is a transformation predicate that removes from every line of a
+// multi-line string the leading whitespace common to all non-blank lines.
+// Lines containing only whitespace are emptied.
+func (b *Builder) Dedent() *Builder {
+	b.p.RegisterTransformation(impl.Dedent())
+	return b
+}
+
+// Lines is a transformation predicate that splits a string into lines,
+// regardless of the newline convention. A trailing newline does not produce
+// an additional empty line.
+func (b *Builder) Lines() *Builder {
+	b.p.RegisterTransformation(impl.Lines())
+	return b
+}
+
+// Words is a transformation predicate that splits a string into words
+// separated by any sequence of whitespace characters.
+func (b *Builder) Words() *Builder {
+	b.p.RegisterTransformation(impl.Words())
+	return b
+}
+
+// Split is a transformation predicate that splits a string into all the
+// substrings separated by `sep`.
+func (b *Builder) Split(sep string) *Builder {
+	b.p.RegisterTransformation(impl.Split(sep))
+	return b
+}
+
+// RuneCount is a transformation predicate that returns the number of Unicode
+// code points in a string, as opposed to `Length()` that counts bytes.
+func (b *Builder) RuneCount() *Builder {
+	b.p.RegisterTransformation(impl.RuneCount())
+	return b
+}
+
+// MatchGroups is a transformation predicate that matches a string against a
+// regular expression, specified either as a string or as a pre-compiled
+// `*regexp.Regexp`, and returns the `[]string` of its capture groups for the
+// first match, excluding the text of the whole match. The transformation
+// fails if the string does not match.
+func (b *Builder) MatchGroups(re any) *Builder {
+	b.p.RegisterTransformation(impl.MatchGroups(re))
+	return b
+}
+
+// NamedGroups is a transformation predicate that matches a string against a
+// regular expression, specified either as a string or as a pre-compiled
+// `*regexp.Regexp`, and returns a `map[string]string` of its named capture
+// groups for the first match. The transformation fails if the string does not
+// match.
+func (b *Builder) NamedGroups(re any) *Builder {
+	b.p.RegisterTransformation(impl.NamedGroups(re))
+	return b
+}
+
+// FindAll is a transformation predicate that returns the `[]string` of all
+// the successive non-overlapping matches of a regular expression in a string,
+// specified either as a string or as a pre-compiled `*regexp.Regexp`.
+func (b *Builder) FindAll(re any) *Builder {
+	b.p.RegisterTransformation(impl.FindAll(re))
+	return b
+}
+
 // From pkg/utils/predicate/impl/string.go
 // ---------------------------------------------------------------------------
 
@@ -529,6 +1229,42 @@ func (b *Builder) Field(keypath string) *Builder {
 	return b
 }
 
+// StrictField is a transformation predicate similar to Field, but that fails
+// with a descriptive error when any part of the `keypath` cannot be resolved,
+// e.g. a misspelled field name, a missing map key or an out-of-range index.
+// See value.StrictField() for more details.
+func (b *Builder) StrictField(keypath string) *Builder {
+	b.p.RegisterTransformation(impl.StrictField(keypath))
+	return b
+}
+
+// MatchesFields tests if the fields of a struct or a map, identified by the
+// keypaths used as keys in `fields`, match the associated values. Each
+// expected value is either a literal value compared for equality, or a
+// sub-expression predicate evaluated on the field. Keypaths that cannot be
+// resolved fail with an error; see value.StrictField() for more details.
+func (b *Builder) MatchesFields(fields map[string]interface{}) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.MatchesFields(fields))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
+// IsEqualIgnoring tests if a value is equal to the specified value, except for
+// the fields identified by the given keypaths. Unexported fields are not
+// compared. See value.Field() for more details about keypaths; keypaths going
+// through sequences apply to all their elements.
+func (b *Builder) IsEqualIgnoring(rhs interface{}, keypaths ...string) *predicate.Predicate {
+	b.p.RegisterPredicate(impl.IsEqualIgnoring(rhs, keypaths...))
+	if b.t != nil {
+		b.t.Helper()
+		Evaluate(b)
+	}
+	return &b.p
+}
+
 // From pkg/utils/predicate/impl/struct.go
 // ---------------------------------------------------------------------------
 
@@ -1,15 +1,21 @@
 package builder_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/bdd"
 	"github.com/maargenton/go-testpredicate/pkg/subexpr"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 	"github.com/maargenton/go-testpredicate/pkg/verify"
 )
 
@@ -17,6 +23,20 @@ import (
 // but only verifies the passing case. Tests for predicates failures and errors
 // are expected to be handled in the `predicate/impl` package.
 
+func TestAggregateAPI(t *testing.T) {
+	var latencies = []time.Duration{
+		12 * time.Millisecond, 15 * time.Millisecond, 48 * time.Millisecond}
+
+	verify.That(t, []int{1, 2, 3}).Sum().Eq(6)
+	verify.That(t, map[string]float64{"a": 1.5, "b": 2}).Sum().IsCloseTo(3.5, 1e-9)
+	verify.That(t, []int{3, 1, 2}).Min().Eq(1)
+	verify.That(t, []int{3, 1, 2}).Max().Eq(3)
+	verify.That(t, []int{1, 2, 3, 4}).Mean().IsCloseTo(2.5, 1e-9)
+	verify.That(t, []int{2, 4, 4, 4, 5, 5, 7, 9}).StdDev().IsCloseTo(2, 1e-9)
+	verify.That(t, latencies).Percentile(99).Lt(50 * time.Millisecond)
+	verify.That(t, []int{1, 5, 2, 3}).CountWhere(subexpr.Value().Lt(3)).Eq(2)
+}
+
 func TestCollectionAPI(t *testing.T) {
 	verify.That(t, []string{"a", "bb", "ccc"}).All(
 		subexpr.Value().Length().Lt(5))
@@ -26,6 +46,47 @@ func TestCollectionAPI(t *testing.T) {
 	verify.That(t, [][]string{{"a", "bb", "cc"}, {"a", "bb", "ccc"}}).All(
 		subexpr.Value().All(
 			subexpr.Value().Length().Lt(5)))
+
+	verify.That(t, []string{"a", "bb", "ccc"}).None(
+		subexpr.Value().IsEmpty())
+	verify.That(t, []string{"a", "bb", "ccc"}).Exactly(2,
+		subexpr.Value().Length().Lt(3))
+	verify.That(t, []string{"a", "bb", "ccc"}).AtLeast(1,
+		subexpr.Value().Length().Ge(3))
+	verify.That(t, []string{"a", "bb", "ccc"}).AtMost(1,
+		subexpr.Value().Length().Ge(3))
+
+	verify.That(t, slices.Values([]int{1, 2, 3})).All(
+		subexpr.Value().Lt(5))
+	verify.That(t, map[string]int{"a": 1, "b": 2}).All(
+		subexpr.Value().Field("Value").Lt(5))
+	verify.That(t, slices.Values([]int{1, 2, 3})).Collect().Eq([]int{1, 2, 3})
+
+	type User struct {
+		ID     int
+		Dept   string
+		Active bool
+	}
+	var users = []User{{3, "eng", true}, {1, "ops", false}, {2, "eng", true}}
+
+	verify.That(t, users).Where(subexpr.Value().Field("Active").Eq(true)).
+		Select("ID").Eq([]int{3, 2})
+	verify.That(t, users).SortBy("ID").Select("ID").Eq([]int{1, 2, 3})
+	verify.That(t, users).Select("ID").Sorted().Eq([]int{1, 2, 3})
+	verify.That(t, users).Select("ID").Reverse().Eq([]int{2, 1, 3})
+	verify.That(t, users).GroupBy("Dept").Field("eng").Length().Eq(2)
+	verify.That(t, [][]int{{1, 2}, {3}}).Flatten().Eq([]int{1, 2, 3})
+	verify.That(t, users).First().Field("ID").Eq(3)
+	verify.That(t, users).Last().Field("ID").Eq(2)
+	verify.That(t, users).At(1).Field("ID").Eq(1)
+
+	verify.That(t, []int{1, 2, 2, 3}).IsSorted()
+	verify.That(t, users).Where(subexpr.Value().Field("Active").Eq(true)).
+		IsSortedBy("Dept")
+	verify.That(t, []int{1, 2, 3}).IsStrictlyIncreasing()
+	verify.That(t, []int{3, 2, 2, 1}).IsDescending()
+	verify.That(t, []int{1, 2, 3}).HasNoDuplicates()
+	verify.That(t, users).HasNoDuplicatesBy("ID")
 }
 
 func TestCompareAPI(t *testing.T) {
@@ -37,6 +98,8 @@ func TestCompareAPI(t *testing.T) {
 	verify.That(t, 123).IsNotEqualTo(124)
 	verify.That(t, 123).Eq(123)
 	verify.That(t, 123).Ne(124)
+	verify.That(t, []int(nil)).EqWith([]int{}, value.EquateEmpty())
+	verify.That(t, time.Unix(0, 0)).EqWith(time.Unix(0, 0).UTC())
 }
 
 type MyError struct {
@@ -61,6 +124,9 @@ func TestErrorAPI(t *testing.T) {
 	var err2 = fmt.Errorf("error: %w", &MyError{Code: 123})
 	var myError *MyError
 	verify.That(t, err2).AsError(&myError).Field("Code").Eq(123)
+	verify.That(t, err2).Is(impl.IsErrorOfType[*MyError]())
+	verify.That(t, err).ErrorChain().Any(subexpr.Value().Eq(sentinel))
+	verify.That(t, errors.Join(err, err2)).ErrorChain().Length().Eq(5)
 }
 
 func TestExtAPI(t *testing.T) {
@@ -91,11 +157,39 @@ func TestExtAPI(t *testing.T) {
 	verify.That(t, 9).Passes(subexpr.Value().Lt(10))
 }
 
+func TestJSONAPI(t *testing.T) {
+	var doc = `{"items": [{"id": 1}, {"id": 2}], "name": "abc"}`
+
+	verify.That(t, doc).IsJSONEqual(`{"name": "abc", "items": [{"id": 1.0}, {"id": 2}]}`)
+	verify.That(t, doc).ParseJSON().Field("name").Eq("abc")
+	verify.That(t, doc).JSONPath("$.items[0].id").Eq(1)
+	verify.That(t, doc).JSONPath("$.items[*].id").Length().Eq(2)
+}
+
+func TestLogicalAPI(t *testing.T) {
+	verify.That(t, "abc").Not(subexpr.Value().IsEmpty())
+	verify.That(t, "abc").AllOf(
+		subexpr.Value().IsNotEmpty(),
+		subexpr.Value().Length().Lt(5))
+	verify.That(t, "abc").AnyOf(
+		subexpr.Value().IsEmpty(),
+		subexpr.Value().Length().Lt(5))
+	verify.That(t, "abc").NoneOf(
+		subexpr.Value().IsEmpty(),
+		subexpr.Value().Contains("x"))
+	verify.That(t, 123).IsOneOf(122, 123, 124)
+}
+
 func TestMapAPI(t *testing.T) {
 	var m = map[string]string{"aaa": "bbb", "ccc": "ddd"}
 
 	verify.That(t, m).MapKeys().IsEqualSet([]string{"aaa", "ccc"})
 	verify.That(t, m).MapValues().IsEqualSet([]string{"bbb", "ddd"})
+	verify.That(t, m).Lookup("aaa").Eq("bbb")
+	verify.That(t, m).HasKey("aaa")
+	verify.That(t, m).HasKeys("aaa", "ccc")
+	verify.That(t, m).HasEntry("aaa", "bbb")
+	verify.That(t, m).ContainsEntries(map[string]string{"ccc": "ddd"})
 }
 
 func TestOrderedAPI(t *testing.T) {
@@ -104,10 +198,24 @@ func TestOrderedAPI(t *testing.T) {
 	verify.That(t, 123).IsGreaterThan(122)
 	verify.That(t, 123).IsGreaterOrEqualTo(123)
 	verify.That(t, 123).IsCloseTo(133, 10)
+	verify.That(t, map[string]float64{"x": 1.01}).IsCloseTo(map[string]float64{"x": 1}, 0.1)
+	verify.That(t, 1005).IsCloseToRel(1000, 0.01)
+	verify.That(t, 0.1+0.2).IsWithinULPs(0.3, 1)
 	verify.That(t, 123).Lt(124)
 	verify.That(t, 123).Le(123)
 	verify.That(t, 123).Gt(122)
 	verify.That(t, 123).Ge(123)
+	verify.That(t, 123).IsBetween(120, 125)
+	verify.That(t, 123).IsStrictlyBetween(120, 125)
+	verify.That(t, 123).IsInRange(123, 125)
+	verify.That(t, big.NewInt(123)).Lt(big.NewInt(124))
+
+	var now = time.Now()
+	verify.That(t, now).IsBefore(now.Add(time.Second))
+	verify.That(t, now).IsAfter(now.Add(-time.Second))
+	verify.That(t, now).IsCloseToTime(now.Add(time.Second), 2*time.Second)
+	verify.That(t, now).IsWithin(time.Minute)
+	verify.That(t, time.Second).Lt(time.Minute)
 }
 
 func TestPanicAPI(t *testing.T) {
@@ -139,13 +247,31 @@ func TestSetAPI(t *testing.T) {
 	verify.That(t, []int{1, 2, 3, 4, 5}).IsDisjointSetFrom([]int{6, 9, 8, 7})
 	verify.That(t, []int{1, 2, 3, 4, 5}).IsSubsetOf([]int{1, 4, 3, 2, 5, 6})
 	verify.That(t, []int{1, 2, 3, 4, 5}).IsSupersetOf([]int{1, 4, 5})
+	verify.That(t, []int{1, 2, 2, 3}).IsPermutationOf([]int{2, 3, 1, 2})
+	verify.That(t, []int{1, 2, 2, 3}).ElementsMatch([]int{2, 3, 1, 2})
 }
 
 func TestStringAPI(t *testing.T) {
 	verify.That(t, "123").Matches(`\d+`)
+	verify.That(t, "123").Matches(regexp.MustCompile(`^\d+$`))
+	verify.That(t, "id=42 user=bob").MatchGroups(`id=(\d+) user=(\w+)`).Eq([]string{"42", "bob"})
+	verify.That(t, "id=42").NamedGroups(`id=(?P<id>\d+)`).Eq(map[string]string{"id": "42"})
+	verify.That(t, "a1 b22").FindAll(`\d+`).Eq([]string{"1", "22"})
 	verify.That(t, 123).ToString().Eq("123")
 	verify.That(t, "aBc").ToLower().Eq("abc")
 	verify.That(t, "aBc").ToUpper().Eq("ABC")
+	verify.That(t, "aBc").EqualFold("AbC")
+	verify.That(t, " a b\n c ").IsEqualIgnoringWhitespace("abc")
+	verify.That(t, "a\nb\nc").ContainsLine("b")
+	verify.That(t, "error: a.txt not found").MatchesGlob("error: * not found")
+	verify.That(t, " abc\n").TrimSpace().Eq("abc")
+	verify.That(t, "a\r\nb").NormalizeNewlines().Eq("a\nb")
+	verify.That(t, " a \n b ").NormalizeWhitespace().Eq("a b")
+	verify.That(t, "  a\n    b").Dedent().Eq("a\n  b")
+	verify.That(t, "a\nb\n").Lines().Eq([]string{"a", "b"})
+	verify.That(t, "a b  c").Words().Eq([]string{"a", "b", "c"})
+	verify.That(t, "a,b,c").Split(",").Eq([]string{"a", "b", "c"})
+	verify.That(t, "héllo").RuneCount().Eq(5)
 }
 
 func TestStructAPI(t *testing.T) {
@@ -154,6 +280,16 @@ func TestStructAPI(t *testing.T) {
 		Value string
 	}{Name: "name", Value: "value"}
 	verify.That(t, v).Field("Name").Eq("name")
+	verify.That(t, v).StrictField("Name").Eq("name")
+	verify.That(t, []any{v}).StrictField("[0].Value").Eq("value")
+	verify.That(t, v).MatchesFields(map[string]any{
+		"Name":  "name",
+		"Value": subexpr.Value().HasPrefix("val"),
+	})
+	verify.That(t, v).IsEqualIgnoring(struct {
+		Name  string
+		Value string
+	}{Name: "name", Value: "other"}, "Value")
 }
 
 func TestTypeAPI(t *testing.T) {
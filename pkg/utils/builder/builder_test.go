@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/builder"
 )
@@ -46,8 +47,100 @@ func TestVerifyCompletness(t *testing.T) {
 	}
 }
 
+func TestEventuallyPassesOnceSampleMatches(t *testing.T) {
+	tt := &testContext{}
+	clock := &fakeClock{}
+	b := builder.New(tt, nil, true)
+	b.Clock = clock
+	n := 0
+	builder.Eventually(b, func() interface{} { n++; return n }, time.Second, 100*time.Millisecond)
+	b.Eq(3)
+
+	if tt.Failed || tt.Output != "" {
+		t.Errorf("\nunexpected failure:\n%v", tt.Output)
+	}
+	if n != 3 || clock.elapsed != 200*time.Millisecond {
+		t.Errorf("\nunexpected sampling: %v samples in %v", n, clock.elapsed)
+	}
+}
+
+func TestEventuallyWithTimeout(t *testing.T) {
+	tt := &testContext{}
+	b := builder.New(tt, nil, true)
+	b.Clock = &fakeClock{}
+	builder.Eventually(b, func() interface{} { return 1 }, time.Second, 300*time.Millisecond)
+	b.Eq(3)
+
+	output := strings.TrimSpace(tt.Output)
+	expectedOutput := "" +
+		"expected: eventually within 1s, value == 3\n" +
+		"value:    1\n" +
+		"attempts: 5\n" +
+		"elapsed:  1s"
+	if !tt.Failed || output != expectedOutput {
+		t.Errorf("\noutput mismatch:\n%v", output)
+	}
+}
+
+func TestEventuallyWithInvalidInterval(t *testing.T) {
+	tt := &testContext{}
+	clock := &fakeClock{}
+	b := builder.New(tt, nil, true)
+	b.Clock = clock
+	n := 0
+	builder.Eventually(b, func() interface{} { n++; return n }, 10*time.Millisecond, 0)
+	b.Eq(0)
+
+	if !tt.Failed || n != 11 || clock.elapsed != 10*time.Millisecond {
+		t.Errorf("\nunexpected sampling: %v samples in %v", n, clock.elapsed)
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	tt := &testContext{}
+	clock := &fakeClock{}
+	b := builder.New(tt, nil, true)
+	b.Clock = clock
+	n := 0
+	builder.Consistently(b, func() interface{} { n++; return n }, time.Second, 100*time.Millisecond)
+	b.Lt(20)
+
+	if tt.Failed || n != 11 {
+		t.Errorf("\nunexpected failure after %v samples:\n%v", n, tt.Output)
+	}
+
+	tt = &testContext{}
+	b = builder.New(tt, nil, true)
+	b.Clock = &fakeClock{}
+	n = 0
+	builder.Consistently(b, func() interface{} { n++; return n }, time.Second, 100*time.Millisecond)
+	b.Lt(3)
+
+	output := strings.TrimSpace(tt.Output)
+	expectedOutput := "" +
+		"expected: consistently within 1s, value < 3\n" +
+		"value:    3\n" +
+		"attempts: 3\n" +
+		"elapsed:  200ms"
+	if !tt.Failed || output != expectedOutput {
+		t.Errorf("\noutput mismatch:\n%v", output)
+	}
+}
+
 // ---------------------------------------------------------------------------
 
+type fakeClock struct {
+	elapsed time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, 0).Add(c.elapsed)
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.elapsed += d
+}
+
 type testContext struct {
 	Output       string
 	Failed       bool
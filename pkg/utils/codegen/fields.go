@@ -55,11 +55,15 @@ func (ff Fields) String() string {
 }
 
 // Fwd returns a string representation of a forwarding list for the
-// fields, assuming all fields have a name
+// fields, assuming all fields have a name. A variadic field is forwarded as
+// such.
 func (ff Fields) Fwd() string {
 	var names []string
 	for _, f := range ff {
 		names = append(names, f.Names...)
 	}
+	if n := len(ff); n > 0 && strings.HasPrefix(ff[n-1].Type, "...") {
+		names[len(names)-1] += "..."
+	}
 	return strings.Join(names, ", ")
 }
@@ -0,0 +1,146 @@
+// GENERATE CODE -- DO NOT EDIT
+
+package example
+
+import (
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/builder"
+	predicates "github.com/maargenton/go-testpredicate/pkg/utils/codegen/forward_api/example/predicates"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+)
+
+// Builder is a custom predicate builder that extends `builder.Builder` with
+// additional predicates and transformations. The built-in transformations
+// return the embedded `*builder.Builder`; custom predicates can still be
+// applied after them through `Is()` and `Eval()`.
+type Builder struct {
+	*builder.Builder
+	t predicate.T
+}
+
+// Verify captures the test context and a value for the purpose of building and
+// evaluating a test predicate through call chaining. The current test will
+// proceed even if the predicate fails.
+func Verify(t predicate.T, v interface{}, ctx ...predicate.ContextValue) *Builder {
+	var b = builder.New(t, v, false)
+	builder.CaptureCallsite(b, 1)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return &Builder{Builder: b, t: t}
+}
+
+// Require captures the test context and a value for the purpose of building
+// and evaluating a test predicate through call chaining. The current test will
+// fail immediately if the predicate fails.
+func Require(t predicate.T, v interface{}, ctx ...predicate.ContextValue) *Builder {
+	var b = builder.New(t, v, true)
+	builder.CaptureCallsite(b, 1)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return &Builder{Builder: b, t: t}
+}
+
+// VerifyEventually is similar to `verify.Eventually()`: the predicate is
+// evaluated on a freshly sampled value every `interval` until it passes, or
+// until `timeout` has elapsed. The current test will proceed even if the
+// predicate fails.
+func VerifyEventually(t predicate.T, f func() interface{}, timeout, interval time.Duration, ctx ...predicate.ContextValue) *Builder {
+	var b = builder.New(t, nil, false)
+	builder.CaptureCallsite(b, 1)
+	builder.Eventually(b, f, timeout, interval)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return &Builder{Builder: b, t: t}
+}
+
+// VerifyConsistently is similar to `verify.Consistently()`: the predicate is
+// evaluated on a freshly sampled value every `interval` and must keep passing
+// until `timeout` has elapsed. The current test will proceed even if the
+// predicate fails.
+func VerifyConsistently(t predicate.T, f func() interface{}, timeout, interval time.Duration, ctx ...predicate.ContextValue) *Builder {
+	var b = builder.New(t, nil, false)
+	builder.CaptureCallsite(b, 1)
+	builder.Consistently(b, f, timeout, interval)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return &Builder{Builder: b, t: t}
+}
+
+// RequireEventually is similar to `require.Eventually()`: the predicate is
+// evaluated on a freshly sampled value every `interval` until it passes, or
+// until `timeout` has elapsed. The current test will fail immediately if the
+// predicate fails.
+func RequireEventually(t predicate.T, f func() interface{}, timeout, interval time.Duration, ctx ...predicate.ContextValue) *Builder {
+	var b = builder.New(t, nil, true)
+	builder.CaptureCallsite(b, 1)
+	builder.Eventually(b, f, timeout, interval)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return &Builder{Builder: b, t: t}
+}
+
+// RequireConsistently is similar to `require.Consistently()`: the predicate is
+// evaluated on a freshly sampled value every `interval` and must keep passing
+// until `timeout` has elapsed. The current test will fail immediately if the
+// predicate fails.
+func RequireConsistently(t predicate.T, f func() interface{}, timeout, interval time.Duration, ctx ...predicate.ContextValue) *Builder {
+	var b = builder.New(t, nil, true)
+	builder.CaptureCallsite(b, 1)
+	builder.Consistently(b, f, timeout, interval)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return &Builder{Builder: b, t: t}
+}
+
+// Value starts a sub-expression predicate for use as part of collection
+// predicate (e.g. .All() or .Any()) that would evaluate it on multiple values
+// and aggregate the results.
+func Value() *Builder {
+	var b = builder.New(nil, nil, false)
+	builder.CaptureCallsite(b, 1)
+	return &Builder{Builder: b}
+}
+
+// ---------------------------------------------------------------------------
+// From pkg/utils/codegen/forward_api/example/predicates/predicates.go
+
+// IsValidUUID tests if a value is a string containing a valid UUID.
+func (b *Builder) IsValidUUID() *predicate.Predicate {
+	if b.t != nil {
+		b.t.Helper()
+	}
+	pDesc, pFunc := predicates.IsValidUUID()
+	return builder.RegisterPredicate(b.Builder, pDesc, pFunc)
+}
+
+// HasStatus tests if a value is a Response with the given status.
+func (b *Builder) HasStatus(code predicates.Status) *predicate.Predicate {
+	if b.t != nil {
+		b.t.Helper()
+	}
+	pDesc, pFunc := predicates.HasStatus(code)
+	return builder.RegisterPredicate(b.Builder, pDesc, pFunc)
+}
+
+// Body is a transformation that extracts the body of a Response.
+func (b *Builder) Body() *Builder {
+	tDesc, tFunc := predicates.Body()
+	builder.RegisterTransformation(b.Builder, tDesc, tFunc)
+	return b
+}
+
+// From pkg/utils/codegen/forward_api/example/predicates/predicates.go
+// ---------------------------------------------------------------------------
@@ -0,0 +1,6 @@
+// Package example demonstrates the generation of a custom predicate builder
+// that extends the built-in predicates with the domain-specific predicates
+// defined in the `predicates` package.
+package example
+
+//go:generate go run github.com/maargenton/go-testpredicate/pkg/utils/codegen/forward_api -o builder_gen.go -package example ./predicates
@@ -0,0 +1,33 @@
+package example_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/codegen/forward_api/example"
+	"github.com/maargenton/go-testpredicate/pkg/utils/codegen/forward_api/example/predicates"
+)
+
+func TestCustomBuilder(t *testing.T) {
+	var resp = predicates.Response{Status: 200, Body: "9f8c6a2e-3b1d-4c5e-8f7a-6d2b1c0e9a8f"}
+
+	example.Verify(t, resp).HasStatus(200)
+	example.Verify(t, resp).Body().IsValidUUID()
+	example.Verify(t, resp).Body().Length().Eq(36)
+	example.Require(t, resp).Field("Body").Is(predicates.IsValidUUID())
+	example.Verify(t, resp).Eval(predicates.Body()).Is(predicates.IsValidUUID())
+	example.Verify(t, []predicates.Response{resp}).All(
+		example.Value().Body().IsValidUUID())
+}
+
+func TestCustomBuilderPolling(t *testing.T) {
+	var status predicates.Status = 100
+	var sample = func() interface{} {
+		status += 50
+		return predicates.Response{Status: status}
+	}
+
+	example.VerifyEventually(t, sample, time.Second, time.Millisecond).HasStatus(200)
+	example.RequireConsistently(t, sample, 10*time.Millisecond, time.Millisecond).
+		Body().IsEmpty()
+}
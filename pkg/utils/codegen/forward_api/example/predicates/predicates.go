@@ -0,0 +1,63 @@
+// Package predicates is an example of a package defining domain-specific
+// predicates, to be forwarded onto a custom builder by `forward_api`.
+package predicates
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+)
+
+// Status is an example of a domain-specific type used as predicate argument.
+type Status int
+
+// Response is an example of a domain-specific type under test.
+type Response struct {
+	Status Status
+	Body   string
+}
+
+var uuidRegexp = regexp.MustCompile(
+	`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// IsValidUUID tests if a value is a string containing a valid UUID.
+func IsValidUUID() (desc string, f predicate.PredicateFunc) {
+	desc = "{} is a valid UUID"
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return false, nil, fmt.Errorf("value of type '%T' is not a string", v)
+		}
+		return uuidRegexp.MatchString(s), nil, nil
+	}
+	return
+}
+
+// HasStatus tests if a value is a Response with the given status.
+func HasStatus(code Status) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{}.Status == %v", code)
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		resp, ok := v.(Response)
+		if !ok {
+			return false, nil, fmt.Errorf("value of type '%T' is not a Response", v)
+		}
+		return resp.Status == code, []predicate.ContextValue{
+			{Name: "status", Value: resp.Status},
+		}, nil
+	}
+	return
+}
+
+// Body is a transformation that extracts the body of a Response.
+func Body() (desc string, f predicate.TransformFunc) {
+	desc = "{}.Body"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		resp, ok := v.(Response)
+		if !ok {
+			return nil, nil, fmt.Errorf("value of type '%T' is not a Response", v)
+		}
+		return resp.Body, nil, nil
+	}
+	return
+}
@@ -1,10 +1,33 @@
+// Command forward_api generates predicate builder methods by forwarding the
+// predicate and transformation functions of one or more `impl`-style packages,
+// i.e. exported functions returning either `(string, predicate.PredicateFunc)`
+// or `(string, predicate.TransformFunc)`.
+//
+// With two positional arguments, it loads the source package and applies the
+// given template, saving the result alongside the template with a .go
+// extension. This is how `builder.Builder` is generated:
+//
+//	forward_api <source_package> <template_file>
+//
+// With the `-o` flag, it generates a custom builder type that embeds
+// `*builder.Builder` and forwards the predicates defined in the listed
+// packages, along with `Verify()`, `Require()` and `Value()` entry points
+// equivalent to `verify.That()`, `require.That()` and `subexpr.Value()`, and
+// their `Eventually` and `Consistently` variants:
+//
+//	//go:generate go run github.com/maargenton/go-testpredicate/pkg/utils/codegen/forward_api -o builder_gen.go -package mypkg ./predicates
 package main
 
 import (
+	_ "embed"
+	"errors"
+	"flag"
 	"fmt"
 	"go/ast"
 	"os"
 	"path/filepath"
+	"sort"
+	"text/template"
 
 	"golang.org/x/tools/go/packages"
 
@@ -28,11 +51,30 @@ type FuncDecl struct {
 	Transformer bool
 }
 
-func extractAPI(pkg *packages.Package) (files []FileDecl, err error) {
+// Import captures the name and path of one package imported by the generated
+// code.
+type Import struct {
+	Name string
+	Path string
+}
+
+// CustomBuilder captures the information passed to the custom builder template.
+type CustomBuilder struct {
+	Package string
+	Type    string
+	Imports []Import
+	Files   []FileDecl
+}
+
+//go:embed custom_builder.tmpl
+var customBuilderTemplate string
+
+func extractAPI(pkg *packages.Package, alias string) (files []FileDecl, err error) {
 	if pkg.Module == nil {
 		return nil, fmt.Errorf("target package is not part of a module")
 	}
 	basePath := pkg.Module.Dir
+	localTypes := localTypeNames(pkg)
 
 	for i, file := range pkg.Syntax {
 		filename := pkg.CompiledGoFiles[i]
@@ -44,12 +86,13 @@ func extractAPI(pkg *packages.Package) (files []FileDecl, err error) {
 		var funcs []FuncDecl
 		for _, decl := range file.Decls {
 			if decl, ok := decl.(*ast.FuncDecl); ok {
-				if decl == nil || decl.Recv != nil {
+				if decl == nil || decl.Recv != nil || decl.Type.TypeParams != nil ||
+					!decl.Name.IsExported() {
 					continue
 				}
 
 				var f = &FuncDecl{}
-				f.Pkg = pkg.Name
+				f.Pkg = alias
 				f.Name = decl.Name.String()
 				f.Rets = codegen.FieldsFromAST(pkg.Fset, decl.Type.Results)
 				if len(f.Rets) != 2 {
@@ -64,6 +107,7 @@ func extractAPI(pkg *packages.Package) (files []FileDecl, err error) {
 					continue
 				}
 
+				qualifyLocalTypes(decl.Type.Params, alias, localTypes)
 				f.Args = codegen.FieldsFromAST(pkg.Fset, decl.Type.Params)
 				comment := ""
 				if decl.Doc != nil {
@@ -84,19 +128,53 @@ func extractAPI(pkg *packages.Package) (files []FileDecl, err error) {
 	return
 }
 
-func run() error {
-	if len(os.Args) < 3 {
-		return fmt.Errorf("usage: forward_api <source_package> <template_name>")
+// localTypeNames returns the names of all the types declared at the top level
+// of the package.
+func localTypeNames(pkg *packages.Package) map[string]bool {
+	var names = map[string]bool{}
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok {
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						names[spec.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// qualifyLocalTypes prefixes the references to types declared in the source
+// package with the package alias, so that the arguments can be declared
+// verbatim in the generated code.
+func qualifyLocalTypes(l *ast.FieldList, alias string, localTypes map[string]bool) {
+	if l == nil {
+		return
 	}
-	sourcePath := os.Args[1]
-	templatePath := os.Args[2]
+	for _, f := range l.List {
+		ast.Inspect(f.Type, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				return false
+			case *ast.Ident:
+				if localTypes[n.Name] {
+					n.Name = alias + "." + n.Name
+				}
+			}
+			return true
+		})
+	}
+}
 
+func runTemplate(sourcePath, templatePath string) error {
 	pkg, err := codegen.LoadPackage(sourcePath)
 	if err != nil {
 		return err
 	}
 
-	files, err := extractAPI(pkg)
+	files, err := extractAPI(pkg, pkg.Name)
 	if err != nil {
 		return err
 	}
@@ -104,8 +182,116 @@ func run() error {
 	return codegen.ApplyTemplate(templatePath, files)
 }
 
+func runCustomBuilder(output, pkgName, typeName, templatePath string, sources []string) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("no source package to forward")
+	}
+
+	var data = CustomBuilder{Package: pkgName, Type: typeName}
+	var names = map[string]bool{"builder": true, "predicate": true, "time": true}
+	var methods = map[string]string{}
+	for _, source := range sources {
+		pkg, err := codegen.LoadPackage(source)
+		if err != nil {
+			return err
+		}
+		if len(pkg.Errors) > 0 {
+			return fmt.Errorf("failed to load package '%v': %v", source, pkg.Errors[0])
+		}
+
+		var alias = pkg.Name
+		for i := 2; names[alias]; i++ {
+			alias = fmt.Sprintf("%v%v", pkg.Name, i)
+		}
+		names[alias] = true
+		data.Imports = append(data.Imports, Import{Name: alias, Path: pkg.PkgPath})
+
+		files, err := extractAPI(pkg, alias)
+		if err != nil {
+			return err
+		}
+		if err := registerMethods(methods, files); err != nil {
+			return err
+		}
+		data.Files = append(data.Files, files...)
+	}
+	sort.Slice(data.Imports, func(i, j int) bool {
+		return data.Imports[i].Path < data.Imports[j].Path
+	})
+
+	if pkgName == "" {
+		pkg, err := codegen.LoadPackage(filepath.Dir(output))
+		if err != nil || pkg.Name == "" {
+			return fmt.Errorf("cannot determine output package name, use -package")
+		}
+		data.Package = pkg.Name
+	}
+
+	var tmpl *template.Template
+	var err error
+	if templatePath != "" {
+		tmpl, err = template.ParseFiles(templatePath)
+	} else {
+		tmpl, err = template.New("custom_builder").Parse(customBuilderTemplate)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load template: %w", err)
+	}
+	return codegen.GenerateFile(tmpl, data, output)
+}
+
+// registerMethods records the name of each function forwarded from `files`
+// along with the name of its source file, and reports an error if any of them
+// was already registered, as the generated methods would conflict.
+func registerMethods(methods map[string]string, files []FileDecl) error {
+	for _, file := range files {
+		for _, f := range file.Funcs {
+			if previous, ok := methods[f.Name]; ok {
+				return fmt.Errorf(
+					"predicate '%v' from '%v' conflicts with the one from '%v'",
+					f.Name, file.Name, previous)
+			}
+			methods[f.Name] = file.Name
+		}
+	}
+	return nil
+}
+
+func run() error {
+	var fs = flag.NewFlagSet("forward_api", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), ""+
+			"usage: forward_api <source_package> <template_file>\n"+
+			"       forward_api -o <output_file> [flags] <source_package>...\n\n")
+		fs.PrintDefaults()
+	}
+	var output = fs.String("o", "",
+		"generate a custom builder into the given output file")
+	var pkgName = fs.String("package", "",
+		"package name of the generated file (default: package of the output directory)")
+	var typeName = fs.String("type", "Builder",
+		"name of the generated custom builder type")
+	var templatePath = fs.String("template", "",
+		"template file used instead of the default custom builder template")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+
+	if *output != "" {
+		return runCustomBuilder(*output, *pkgName, *typeName, *templatePath, fs.Args())
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("invalid arguments")
+	}
+	return runTemplate(fs.Arg(0), fs.Arg(1))
+}
+
 func main() {
 	if err := run(); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
 		fmt.Printf("%v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/codegen"
+)
+
+func TestQualifyLocalTypes(t *testing.T) {
+	var localTypes = map[string]bool{"Point": true, "Options": true}
+	var inputs = []struct {
+		params   string
+		expected string
+	}{
+		{"p Point", "p preds.Point"},
+		{"p *Point, n int", "p *preds.Point, n int"},
+		{"l []Point, m map[string]Options", "l []preds.Point, m map[string]preds.Options"},
+		{"f func(Point) Options", "f func(preds.Point) preds.Options"},
+		{"v ...Point", "v ...preds.Point"},
+		{"p other.Point", "p other.Point"},
+		{"s string, err error", "s string, err error"},
+	}
+
+	for _, input := range inputs {
+		var fset = token.NewFileSet()
+		file, err := parser.ParseFile(fset, "input.go",
+			"package p\nfunc f("+input.params+") {}\n", 0)
+		if err != nil {
+			t.Fatalf("\nfailed to parse %q: %v", input.params, err)
+		}
+		var params = file.Decls[0].(*ast.FuncDecl).Type.Params
+		qualifyLocalTypes(params, "preds", localTypes)
+
+		r := codegen.FieldsFromAST(fset, params).String()
+		if r != input.expected {
+			t.Errorf("\nunexpected result from qualifyLocalTypes(%q)\nexpected: %v\nactual:   %v",
+				input.params, input.expected, r)
+		}
+	}
+}
+
+func TestRegisterMethods(t *testing.T) {
+	var inputs = []struct {
+		files [][]FileDecl
+		err   string
+	}{
+		{
+			files: [][]FileDecl{
+				{{Name: "a/ops.go", Funcs: []FuncDecl{{Name: "IsOdd"}, {Name: "IsEven"}}}},
+				{{Name: "b/ops.go", Funcs: []FuncDecl{{Name: "IsPrime"}}}},
+			},
+		},
+		{
+			files: [][]FileDecl{
+				{{Name: "a/ops.go", Funcs: []FuncDecl{{Name: "IsOdd"}}}},
+				{{Name: "b/ops.go", Funcs: []FuncDecl{{Name: "IsEven"}, {Name: "IsOdd"}}}},
+			},
+			err: "predicate 'IsOdd' from 'b/ops.go' conflicts with the one from 'a/ops.go'",
+		},
+		{
+			files: [][]FileDecl{{
+				{Name: "a/ops.go", Funcs: []FuncDecl{{Name: "IsOdd"}}},
+				{Name: "a/more.go", Funcs: []FuncDecl{{Name: "IsOdd"}}},
+			}},
+			err: "predicate 'IsOdd' from 'a/more.go' conflicts with the one from 'a/ops.go'",
+		},
+	}
+
+	for i, input := range inputs {
+		var methods = map[string]string{}
+		var err error
+		for _, files := range input.files {
+			if err = registerMethods(methods, files); err != nil {
+				break
+			}
+		}
+		var msg string
+		if err != nil {
+			msg = err.Error()
+		}
+		if msg != input.err {
+			t.Errorf("\nunexpected error from registerMethods() for input %v\nexpected: %v\nactual:   %v",
+				i, input.err, msg)
+		}
+	}
+}
@@ -20,17 +20,15 @@ import (
 )
 
 // LoadPackage loads and parses the source code of a package names relative to
-// the current working directory
+// the current working directory. Only the syntax tree is loaded, without type
+// information.
 func LoadPackage(name string) (pkg *packages.Package, err error) {
 	cfg := &packages.Config{
 		Mode: packages.NeedName |
 			packages.NeedFiles |
 			packages.NeedCompiledGoFiles |
 			packages.NeedImports |
-			packages.NeedTypes |
-			packages.NeedTypesSizes |
 			packages.NeedSyntax |
-			packages.NeedTypesInfo |
 			packages.NeedModule,
 		Tests:      false,
 		BuildFlags: []string{},
@@ -57,20 +55,26 @@ func ApplyTemplate(templateFn string, data interface{}) error {
 		return fmt.Errorf("failed to load template: %w", err)
 	}
 
+	outputFn := strings.ReplaceAll(templateFn, ".tmpl", ".go")
+	return GenerateFile(tmpl, data, outputFn)
+}
+
+// GenerateFile executes a template on the `data` object, formats the output
+// through go-imports, and save the result to `outputFn`.
+func GenerateFile(tmpl *template.Template, data interface{}, outputFn string) error {
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, data)
+	err := tmpl.Execute(&buf, data)
 	if err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	output, err := imports.Process("", buf.Bytes(), &imports.Options{
+	output, err := imports.Process(outputFn, buf.Bytes(), &imports.Options{
 		AllErrors: true, Comments: true, TabIndent: true, TabWidth: 8,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to format generate code: %w", err)
 	}
 
-	outputFn := strings.ReplaceAll(templateFn, ".tmpl", ".go")
 	err = ioutil.WriteFile(outputFn, output, 0644)
 	return err
 }
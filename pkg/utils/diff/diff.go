@@ -0,0 +1,393 @@
+// Package diff computes the differences between two values and formats them
+// for display in the context of a failed predicate. The structural diff walks
+// both values in parallel and reports each difference with a keypath that
+// follows the same vocabulary as `value.Field()`, while the unified diff
+// compares multi-line strings line by line.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+// Kind identifies the nature of a Difference.
+type Kind int
+
+// Possible kinds of difference between the value and the expected value.
+const (
+	// Changed indicates that the value differs from the expected value
+	Changed Kind = iota
+	// Added indicates an element that is present in the value but not in the
+	// expected value.
+	Added
+	// Removed indicates an element that is present in the expected value but
+	// missing from the value.
+	Removed
+)
+
+// Difference captures one difference between the value under test and the
+// expected value. `Keypath` locates the difference from the root of both
+// values, e.g. `.Users[3].Address.Zip`; it is empty when the difference is at
+// the root. Elements removed from a sequence are addressed by their index in
+// the expected value, all others by their index in the value.
+type Difference struct {
+	Keypath  string
+	Kind     Kind
+	Value    interface{}
+	Expected interface{}
+}
+
+// Compare walks `value` and `expected` in parallel and returns the list of
+// differences between them, or nil if they are equal according to
+// `value.CompareUnordered()`. Struct fields are compared one by one, map
+// entries are matched by key, and sequences are aligned on their longest
+// common subsequence so that inserted and removed elements are reported as
+// such.
+func Compare(value, expected interface{}) []Difference {
+	var d = differ{visited: map[visit]bool{}}
+	d.compare("", reflect.ValueOf(value), reflect.ValueOf(expected))
+	return d.diffs
+}
+
+// CompareWith is similar to `Compare()`, but uses `value.DeepEqual()` with the
+// given options to decide which parts of the values are equal.
+func CompareWith(v, expected interface{}, opts ...value.EqualOption) []Difference {
+	var d = differ{visited: map[visit]bool{}, deepEqual: true, options: opts}
+	d.compare("", reflect.ValueOf(v), reflect.ValueOf(expected))
+	return d.diffs
+}
+
+// CompareIgnoring is similar to `Compare()`, but drops all the differences
+// located at or below any of the given keypaths. Keypaths follow the
+// `value.Field()` notation and apply to every element of the sequences
+// traversed along the way, e.g. `ID` or `Users.CreatedAt`. Unexported struct
+// fields are not compared.
+func CompareIgnoring(value, expected interface{}, keypaths ...string) []Difference {
+	var d = differ{visited: map[visit]bool{}, ignoring: true, ignored: keypaths}
+	d.compare("", reflect.ValueOf(value), reflect.ValueOf(expected))
+	return d.diffs
+}
+
+// Format returns a multi-line textual representation of a list of
+// differences, one difference per line, truncated after a reasonable number
+// of entries.
+func Format(diffs []Difference) string {
+	var lines []string
+	for i, d := range diffs {
+		if i >= maxFormattedDifferences {
+			lines = append(lines, fmt.Sprintf(
+				"... %v more differences", len(diffs)-i))
+			break
+		}
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String returns a single line representation of the difference, prefixed
+// with `~`, `+` or `-` depending on its kind.
+func (d Difference) String() string {
+	var keypath = FormatKeypath(d.Keypath)
+	switch d.Kind {
+	case Added:
+		return fmt.Sprintf("+ %v: %v", keypath, formatter.FormatValue(d.Value))
+	case Removed:
+		return fmt.Sprintf("- %v: %v", keypath, formatter.FormatValue(d.Expected))
+	default:
+		return fmt.Sprintf("~ %v: %v → %v", keypath,
+			formatter.FormatValue(d.Expected),
+			formatter.FormatValue(d.Value))
+	}
+}
+
+// FormatKeypath returns a displayable version of a keypath, where the root of
+// the value is represented as `.`.
+func FormatKeypath(keypath string) string {
+	if keypath == "" || strings.HasPrefix(keypath, "[") {
+		return "." + keypath
+	}
+	return keypath
+}
+
+const maxFormattedDifferences = 50
+
+// maxSequenceAlignment is the maximum product of the lengths of two sequences
+// for which an alignment is computed; longer sequences are compared index by
+// index.
+const maxSequenceAlignment = 1 << 20
+
+var formatter = prettyprint.Formatter{
+	Width:      80,
+	MinWidth:   40,
+	WrapPrefix: "↩",
+	WrapSuffix: "↪",
+	MaxWrapped: 10,
+	IndentStr:  "\t",
+	NewlineStr: "\n  ",
+}
+
+// ---------------------------------------------------------------------------
+// Structural comparison
+
+type visit struct {
+	v, e uintptr
+	typ  reflect.Type
+}
+
+type differ struct {
+	diffs    []Difference
+	visited  map[visit]bool
+	ignoring bool
+	ignored  []string
+
+	deepEqual bool
+	options   []value.EqualOption
+}
+
+func (d *differ) compare(path string, v, e reflect.Value) {
+	if d.isIgnored(path) || d.equal(v, e) {
+		return
+	}
+
+	// When ignoring some keypaths, the walk through the values is authoritative
+	// and a value is not reported as changed if none of its parts are.
+	var n = len(d.diffs)
+	var walked = false
+	if v.IsValid() && e.IsValid() && v.Type() == e.Type() {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if !v.IsNil() && !e.IsNil() {
+				if !d.enter(v, e) {
+					return
+				}
+				d.compare(path, v.Elem(), e.Elem())
+				walked = true
+			}
+
+		case reflect.Interface:
+			if !v.IsNil() && !e.IsNil() {
+				d.compare(path, v.Elem(), e.Elem())
+				walked = true
+			}
+
+		case reflect.Struct:
+			// Structs without exported fields, like time.Time, are opaque
+			// and compared as a whole.
+			if hasExportedFields(v.Type()) {
+				d.compareStructs(path, v, e)
+				walked = true
+			}
+
+		case reflect.Map:
+			if !v.IsNil() && !e.IsNil() {
+				d.compareMaps(path, v, e)
+				walked = true
+			}
+
+		case reflect.Array, reflect.Slice:
+			d.compareSequences(path, v, e)
+			walked = true
+		}
+	}
+
+	if len(d.diffs) == n && !(walked && d.ignoring) {
+		d.diffs = append(d.diffs, Difference{
+			Keypath:  path,
+			Kind:     Changed,
+			Value:    valueInterface(v),
+			Expected: valueInterface(e),
+		})
+	}
+}
+
+// enter records a pair of pointers being visited, and returns false if the
+// pair was already visited to prevent infinite recursion on cyclic values. Any
+// difference below an already visited pair is reported at its first visit.
+func (d *differ) enter(v, e reflect.Value) bool {
+	var k = visit{v.Pointer(), e.Pointer(), v.Type()}
+	if d.visited[k] {
+		return false
+	}
+	d.visited[k] = true
+	return true
+}
+
+func (d *differ) compareStructs(path string, v, e reflect.Value) {
+	var t = v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		d.compare(path+"."+t.Field(i).Name, v.Field(i), e.Field(i))
+	}
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *differ) compareMaps(path string, v, e reflect.Value) {
+	for _, k := range value.SortedKeys(v, e) {
+		var vv, ev = v.MapIndex(k), e.MapIndex(k)
+		var keypath = value.AppendKeypath(path, k)
+		switch {
+		case !ev.IsValid():
+			d.report(Difference{
+				Keypath: keypath,
+				Kind:    Added,
+				Value:   valueInterface(vv),
+			})
+		case !vv.IsValid():
+			d.report(Difference{
+				Keypath:  keypath,
+				Kind:     Removed,
+				Expected: valueInterface(ev),
+			})
+		default:
+			d.compare(keypath, vv, ev)
+		}
+	}
+}
+
+// compareSequences aligns the two sequences on their longest common
+// subsequence and reports each mismatched run. Within a run, elements present
+// on both sides are compared recursively and the remaining ones are reported
+// as added or removed.
+func (d *differ) compareSequences(path string, v, e reflect.Value) {
+	var n, m = v.Len(), e.Len()
+	if n*m > maxSequenceAlignment {
+		d.compareRun(path, v, e, 0, n, 0, m)
+		return
+	}
+
+	var match = make([][]bool, n)
+	for i := range match {
+		match[i] = make([]bool, m)
+		for j := range match[i] {
+			match[i][j] = d.equal(v.Index(i), e.Index(j))
+		}
+	}
+
+	var lcs = make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if match[i][j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var i, j = 0, 0
+	for i < n || j < m {
+		if i < n && j < m && match[i][j] {
+			i, j = i+1, j+1
+			continue
+		}
+		var i0, j0 = i, j
+		for (i < n || j < m) && !(i < n && j < m && match[i][j]) {
+			if j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]) {
+				j++
+			} else {
+				i++
+			}
+		}
+		d.compareRun(path, v, e, i0, i, j0, j)
+	}
+}
+
+func (d *differ) compareRun(path string, v, e reflect.Value, i0, i1, j0, j1 int) {
+	var k = min(i1-i0, j1-j0)
+	for x := 0; x < k; x++ {
+		d.compare(indexPath(path, i0+x), v.Index(i0+x), e.Index(j0+x))
+	}
+	for i := i0 + k; i < i1; i++ {
+		d.report(Difference{
+			Keypath: indexPath(path, i),
+			Kind:    Added,
+			Value:   valueInterface(v.Index(i)),
+		})
+	}
+	for j := j0 + k; j < j1; j++ {
+		d.report(Difference{
+			Keypath:  indexPath(path, j),
+			Kind:     Removed,
+			Expected: valueInterface(e.Index(j)),
+		})
+	}
+}
+
+// report records an added or removed element, unless its keypath is ignored.
+func (d *differ) report(diff Difference) {
+	if !d.isIgnored(diff.Keypath) {
+		d.diffs = append(d.diffs, diff)
+	}
+}
+
+// isIgnored returns true if a keypath is located at or below any of the
+// ignored keypaths, disregarding sequence indexes.
+func (d *differ) isIgnored(path string) bool {
+	if len(d.ignored) == 0 {
+		return false
+	}
+	var p = strings.TrimPrefix(indexPattern.ReplaceAllString(path, ""), ".")
+	for _, k := range d.ignored {
+		if p == k || strings.HasPrefix(p, k+".") || strings.HasPrefix(p, k+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+var indexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// Structural comparison
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions
+
+func (d *differ) equal(v, e reflect.Value) bool {
+	if !v.IsValid() || !e.IsValid() {
+		return v.IsValid() == e.IsValid()
+	}
+	if !v.CanInterface() || !e.CanInterface() {
+		return false
+	}
+	var eq bool
+	var err error
+	if d.deepEqual {
+		eq, err = value.DeepEqual(v.Interface(), e.Interface(), d.options...)
+	} else {
+		eq, err = value.CompareUnordered(v.Interface(), e.Interface())
+	}
+	return eq && err == nil
+}
+
+func valueInterface(v reflect.Value) interface{} {
+	if v.IsValid() && v.CanInterface() {
+		return v.Interface()
+	}
+	return nil
+}
+
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%v[%v]", path, i)
+}
+
+// Helper functions
+// ---------------------------------------------------------------------------
@@ -0,0 +1,180 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+type Address struct {
+	City string
+	Zip  string
+}
+
+type User struct {
+	Name    string
+	Address *Address
+	Tags    []string
+}
+
+func verifyDiff(t *testing.T, value, expected interface{}, lines ...string) {
+	t.Helper()
+	var s = diff.Format(diff.Compare(value, expected))
+	var e = strings.Join(lines, "\n")
+	if s != e {
+		t.Errorf("\ndiff mismatch\nexpected:\n%v\nactual:\n%v", e, s)
+	}
+}
+
+func TestCompareEqualValues(t *testing.T) {
+	var u = User{Name: "Alice", Address: &Address{City: "SF", Zip: "94107"}}
+	if diffs := diff.Compare(u, u); diffs != nil {
+		t.Errorf("\nunexpected differences: %v", diffs)
+	}
+	if diffs := diff.Compare([]int{1, 2}, []int64{1, 2}); diffs != nil {
+		t.Errorf("\nunexpected differences: %v", diffs)
+	}
+}
+
+func TestCompareScalarValues(t *testing.T) {
+	verifyDiff(t, 123, 124, "~ .: 124 → 123")
+	verifyDiff(t, "123", 123, `~ .: 123 → "123"`)
+}
+
+func TestCompareStructs(t *testing.T) {
+	var users = []User{
+		{Name: "Alice", Address: &Address{City: "SF", Zip: "94107"}},
+		{Name: "Bob", Address: &Address{City: "SF", Zip: "94107"}},
+	}
+	var expected = []User{
+		{Name: "Alice", Address: &Address{City: "SF", Zip: "94107"}},
+		{Name: "Bob", Address: &Address{City: "SF", Zip: "94110"}},
+	}
+	verifyDiff(t, users, expected, `~ .[1].Address.Zip: "94110" → "94107"`)
+	verifyDiff(t,
+		map[string][]User{"users": users},
+		map[string][]User{"users": expected},
+		`~ .users[1].Address.Zip: "94110" → "94107"`)
+}
+
+func TestCompareMaps(t *testing.T) {
+	verifyDiff(t,
+		map[string]int{"a": 1, "b": 2, "c.d": 3},
+		map[string]int{"a": 1, "b": 3, "e": 4},
+		"~ .b: 3 → 2",
+		`+ .["c.d"]: 3`,
+		"- .e: 4")
+	verifyDiff(t,
+		map[int]string{1: "a", 2: "b"},
+		map[int]string{1: "a", 2: "c"},
+		`~ .[2]: "c" → "b"`)
+}
+
+func TestCompareSequences(t *testing.T) {
+	verifyDiff(t,
+		[]int{1, 2, 3, 4, 5},
+		[]int{1, 3, 4, 6, 5},
+		"+ .[1]: 2",
+		"- .[3]: 6")
+	verifyDiff(t,
+		[]int{1, 2, 3},
+		[]int{1, 5, 3},
+		"~ .[1]: 5 → 2")
+	verifyDiff(t,
+		[]int{1, 2, 3},
+		[]int{},
+		"+ .[0]: 1",
+		"+ .[1]: 2",
+		"+ .[2]: 3")
+}
+
+func TestCompareCyclicValues(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+	var a, b = &Node{Value: 1}, &Node{Value: 1}
+	a.Next, b.Next = a, b
+	if diffs := diff.Compare(a, b); diffs != nil {
+		t.Errorf("\nunexpected differences: %v", diffs)
+	}
+
+	var c = &Node{Value: 2}
+	c.Next = c
+	verifyDiff(t, a, c, "~ .Value: 2 → 1")
+}
+
+func TestFormatTruncatesLongDiffs(t *testing.T) {
+	var value = make([]int, 100)
+	for i := range value {
+		value[i] = i
+	}
+	var lines = strings.Split(diff.Format(diff.Compare(value, []int{})), "\n")
+	if len(lines) != 51 || lines[50] != "... 50 more differences" {
+		t.Errorf("\nunexpected output:\n%v", strings.Join(lines, "\n"))
+	}
+}
+
+func TestCompareIgnoring(t *testing.T) {
+	type Record struct {
+		ID    int
+		Name  string
+		Users []User
+		cache map[string]int
+	}
+	var value = Record{ID: 1, Name: "a", Users: []User{
+		{Name: "Alice", Address: &Address{City: "SF", Zip: "94107"}},
+		{Name: "Bob", Address: &Address{City: "LA", Zip: "90001"}},
+	}, cache: map[string]int{"a": 1}}
+	var expected = Record{ID: 2, Name: "a", Users: []User{
+		{Name: "Alice", Address: &Address{City: "SF", Zip: "94110"}},
+		{Name: "Bob", Address: &Address{City: "NY", Zip: "10001"}},
+	}}
+
+	if diffs := diff.CompareIgnoring(value, expected, "ID", "Users.Address"); diffs != nil {
+		t.Errorf("\nunexpected differences: %v", diffs)
+	}
+	var s = diff.Format(diff.CompareIgnoring(value, expected, "ID", "Users.Address.Zip"))
+	var e = `~ .Users[1].Address.City: "NY" → "LA"`
+	if s != e {
+		t.Errorf("\ndiff mismatch\nexpected:\n%v\nactual:\n%v", e, s)
+	}
+}
+
+func TestCompareIgnoringOpaqueStructs(t *testing.T) {
+	type Event struct {
+		ID   int
+		Time time.Time
+	}
+	var now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var value = Event{ID: 1, Time: now}
+	var expected = Event{ID: 2, Time: now.Add(time.Hour)}
+
+	if diffs := diff.CompareIgnoring(value, expected, "ID", "Time"); diffs != nil {
+		t.Errorf("\nunexpected differences: %v", diffs)
+	}
+	var diffs = diff.CompareIgnoring(value, expected, "ID")
+	if len(diffs) != 1 || diffs[0].Keypath != ".Time" || diffs[0].Kind != diff.Changed {
+		t.Errorf("\nunexpected differences: %v", diffs)
+	}
+}
+
+func TestCompareWith(t *testing.T) {
+	type Sample struct {
+		Name   string
+		Values []float64
+		Tags   []string
+	}
+	var v = Sample{Name: "a", Values: []float64{1.0001, 2.5}, Tags: []string{}}
+	var e = Sample{Name: "a", Values: []float64{1.0, 2.0}}
+
+	var s = diff.Format(diff.CompareWith(v, e,
+		value.EquateApprox(1e-3), value.EquateEmpty()))
+	var expected = "~ .Values[1]: 2 → 2.5"
+	if s != expected {
+		t.Errorf("\ndiff mismatch\nexpected:\n%v\nactual:\n%v", expected, s)
+	}
+}
@@ -0,0 +1,326 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Unified returns a unified diff between two multi-line strings, from the
+// `expected` string to the `value` string, or an empty string if they are
+// equal. Lines are aligned with the Myers algorithm and grouped into hunks with
+// a few lines of context; lines too different to be aligned within a bounded
+// amount of memory are reported as a block of removed lines followed by a
+// block of added lines. Each changed line is followed by a `?` line pointing
+// at the first rune that differs from its counterpart, and invisible
+// characters like trailing whitespace and carriage returns are made visible on
+// changed lines.
+func Unified(value, expected string) string {
+	if value == expected {
+		return ""
+	}
+	var a, b = splitLines(expected), splitLines(value)
+	var edits = myers(a, b)
+
+	var buf strings.Builder
+	buf.WriteString("--- expected\n+++ value")
+	for _, h := range hunks(edits, contextLines) {
+		writeHunk(&buf, a, b, edits[h[0]:h[1]])
+	}
+	return buf.String()
+}
+
+// IsMultiline returns true if the string contains more than one line.
+func IsMultiline(s string) bool {
+	return strings.Contains(s, "\n")
+}
+
+const contextLines = 3
+
+// splitLines splits a string into lines, each line retaining its line
+// terminator so that differences in line endings are detected.
+func splitLines(s string) []string {
+	var lines = strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// ---------------------------------------------------------------------------
+// Myers diff algorithm
+
+type op int
+
+const (
+	opEqual op = iota
+	opDelete
+	opInsert
+)
+
+// edit captures one step of the edit script; `a` and `b` are the indexes of
+// the line in the expected and actual sequences respectively.
+type edit struct {
+	op   op
+	a, b int
+}
+
+// maxLineAlignment is the maximum number of intermediate values recorded while
+// aligning lines with the Myers algorithm, bounding its memory usage to
+// O(maxLineAlignment) instead of O((n+m)^2). Beyond that, the differing lines
+// are reported as a single block of deleted lines followed by inserted lines.
+const maxLineAlignment = 1 << 20
+
+// myers returns the shortest edit script transforming `a` into `b`, following
+// E. Myers, "An O(ND) Difference Algorithm and Its Variations" (1986). Common
+// leading and trailing lines are matched upfront, and if the remaining lines
+// are too different to be aligned within `maxLineAlignment`, they are all
+// reported as deleted then inserted.
+func myers(a, b []string) []edit {
+	var prefix = 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	var suffix = 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []edit
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{opEqual, i, i})
+	}
+	var ma, mb = a[prefix : len(a)-suffix], b[prefix : len(b)-suffix]
+	var middle, ok = align(ma, mb)
+	if !ok {
+		middle = nil
+		for i := range ma {
+			middle = append(middle, edit{opDelete, i, 0})
+		}
+		for j := range mb {
+			middle = append(middle, edit{opInsert, len(ma), j})
+		}
+	}
+	for _, e := range middle {
+		edits = append(edits, edit{e.op, e.a + prefix, e.b + prefix})
+	}
+	for i := 0; i < suffix; i++ {
+		edits = append(edits, edit{opEqual, len(a) - suffix + i, len(b) - suffix + i})
+	}
+	return edits
+}
+
+// align computes the shortest edit script between `a` and `b`, or returns
+// false if the trace needed to recover it would exceed `maxLineAlignment`
+// values. The trace only records the diagonals reachable at each step.
+func align(a, b []string) ([]edit, bool) {
+	var n, m = len(a), len(b)
+	var offset = n + m + 1
+	var v = make([]int, 2*offset+1)
+	var trace [][]int
+	var recorded = 0
+
+	for d := 0; d <= n+m; d++ {
+		recorded += 2*d + 1
+		if recorded > maxLineAlignment {
+			return nil, false
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			var y = x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m), true
+			}
+		}
+	}
+	return nil, true
+}
+
+// backtrack recovers the edit script from the trace of the Myers algorithm,
+// where `trace[d][d+k]` holds the furthest x reached on diagonal k before
+// step d.
+func backtrack(trace [][]int, x, y int) []edit {
+	var edits []edit
+	for d := len(trace) - 1; d >= 0; d-- {
+		var v = trace[d]
+		var k = x - y
+		var prevK int
+		if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		var prevX = 0
+		if d > 0 {
+			prevX = v[d+prevK]
+		}
+		var prevY = prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, edit{opEqual, x, y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{opInsert, prevX, prevY})
+			} else {
+				edits = append(edits, edit{opDelete, prevX, prevY})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// Myers diff algorithm
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Unified output
+
+// hunks groups the changes of an edit script into ranges of edits, including
+// up to `n` unchanged lines around each change. Changes separated by no more
+// than `2*n` unchanged lines are merged into the same hunk.
+func hunks(edits []edit, n int) (r [][2]int) {
+	for i := 0; i < len(edits); i++ {
+		if edits[i].op == opEqual {
+			continue
+		}
+		var start = max(0, i-n)
+		var end = i + 1
+		for j := i + 1; j < len(edits) && j <= end+2*n; j++ {
+			if edits[j].op != opEqual {
+				end = j + 1
+			}
+		}
+		end = min(len(edits), end+n)
+		if len(r) > 0 && r[len(r)-1][1] >= start {
+			r[len(r)-1][1] = end
+		} else {
+			r = append(r, [2]int{start, end})
+		}
+		i = end - 1
+	}
+	return r
+}
+
+func writeHunk(buf *strings.Builder, a, b []string, edits []edit) {
+	var aStart, bStart = edits[0].a, edits[0].b
+	var aLen, bLen = 0, 0
+	for _, e := range edits {
+		if e.op != opInsert {
+			aLen++
+		}
+		if e.op != opDelete {
+			bLen++
+		}
+	}
+	fmt.Fprintf(buf, "\n@@ -%v +%v @@",
+		formatRange(aStart, aLen), formatRange(bStart, bLen))
+
+	for i := 0; i < len(edits); {
+		if edits[i].op == opEqual {
+			writeLine(buf, " ", a[edits[i].a], false)
+			i++
+			continue
+		}
+
+		var deleted, inserted []string
+		for ; i < len(edits) && edits[i].op == opDelete; i++ {
+			deleted = append(deleted, a[edits[i].a])
+		}
+		for ; i < len(edits) && edits[i].op == opInsert; i++ {
+			inserted = append(inserted, b[edits[i].b])
+		}
+		for j, line := range deleted {
+			writeLine(buf, "-", line, true)
+			if j < len(inserted) {
+				writeMarker(buf, line, inserted[j])
+			}
+		}
+		for j, line := range inserted {
+			writeLine(buf, "+", line, true)
+			if j < len(deleted) {
+				writeMarker(buf, line, deleted[j])
+			}
+		}
+	}
+}
+
+func formatRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%v,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%v", start+1)
+	}
+	return fmt.Sprintf("%v,%v", start+1, length)
+}
+
+func writeLine(buf *strings.Builder, prefix, line string, changed bool) {
+	var missingNewline = !strings.HasSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\n")
+	if changed {
+		line = visible(line)
+	}
+	buf.WriteString("\n" + prefix + line)
+	if missingNewline {
+		buf.WriteString("\n\\ No newline at end of string")
+	}
+}
+
+// writeMarker writes a `?` line with a `^` marker under the first rune of
+// `line` that differs from `other`, unless both lines only differ by their
+// terminating newline.
+func writeMarker(buf *strings.Builder, line, other string) {
+	line = visible(strings.TrimSuffix(line, "\n"))
+	other = visible(strings.TrimSuffix(other, "\n"))
+	if line == other {
+		return
+	}
+
+	var padding strings.Builder
+	for len(line) > 0 && len(other) > 0 {
+		r1, n1 := utf8.DecodeRuneInString(line)
+		r2, n2 := utf8.DecodeRuneInString(other)
+		if r1 != r2 {
+			break
+		}
+		if r1 == '\t' {
+			padding.WriteRune('\t')
+		} else {
+			padding.WriteRune(' ')
+		}
+		line, other = line[n1:], other[n2:]
+	}
+	buf.WriteString("\n?" + padding.String() + "^")
+}
+
+var trailingWhitespace = strings.NewReplacer(" ", "·", "\t", "→", "\r", "␍")
+
+// visible replaces carriage returns and trailing whitespace with visible
+// symbols.
+func visible(line string) string {
+	var trimmed = strings.TrimRight(line, " \t\r")
+	var trailing = line[len(trimmed):]
+	trailing = trailingWhitespace.Replace(trailing)
+	trimmed = strings.ReplaceAll(trimmed, "\r", "␍")
+	return trimmed + trailing
+}
+
+// Unified output
+// ---------------------------------------------------------------------------
@@ -0,0 +1,145 @@
+package diff_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
+)
+
+func verifyUnified(t *testing.T, value, expected string, lines ...string) {
+	t.Helper()
+	var s = diff.Unified(value, expected)
+	var e = strings.Join(lines, "\n")
+	if s != e {
+		t.Errorf("\ndiff mismatch\nexpected:\n%v\nactual:\n%v", e, s)
+	}
+}
+
+func TestUnifiedEqualStrings(t *testing.T) {
+	verifyUnified(t, "a\nb\n", "a\nb\n")
+}
+
+func TestUnifiedChangedLine(t *testing.T) {
+	verifyUnified(t,
+		"a\nb\nc\nd\nexample\nf\ng\nh\ni\n",
+		"a\nb\nc\nd\nexamine\nf\ng\nh\ni\n",
+		"--- expected",
+		"+++ value",
+		"@@ -2,7 +2,7 @@",
+		" b",
+		" c",
+		" d",
+		"-examine",
+		"?    ^",
+		"+example",
+		"?    ^",
+		" f",
+		" g",
+		" h",
+	)
+}
+
+func TestUnifiedInsertedAndRemovedLines(t *testing.T) {
+	verifyUnified(t,
+		"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n",
+		"1\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n12.5\n13\n",
+		"--- expected",
+		"+++ value",
+		"@@ -1,4 +1,5 @@",
+		" 1",
+		"+2",
+		" 3",
+		" 4",
+		" 5",
+		"@@ -9,5 +10,4 @@",
+		" 10",
+		" 11",
+		" 12",
+		"-12.5",
+		" 13",
+	)
+}
+
+func TestUnifiedInvisibleDifferences(t *testing.T) {
+	verifyUnified(t,
+		"a\r\nb \n",
+		"a\nb\n",
+		"--- expected",
+		"+++ value",
+		"@@ -1,2 +1,2 @@",
+		"-a",
+		"? ^",
+		"-b",
+		"? ^",
+		"+a␍",
+		"? ^",
+		"+b·",
+		"? ^",
+	)
+	verifyUnified(t,
+		"a\nb",
+		"a\nb\n",
+		"--- expected",
+		"+++ value",
+		"@@ -1,2 +1,2 @@",
+		" a",
+		"-b",
+		"+b",
+		`\ No newline at end of string`,
+	)
+}
+
+func TestUnifiedLargeInputs(t *testing.T) {
+	var value, expected strings.Builder
+	for i := 0; i < 4000; i++ {
+		fmt.Fprintf(&value, "value line %v\n", i)
+		fmt.Fprintf(&expected, "expected line %v\n", i)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	var s = diff.Unified(value.String(), expected.String())
+	runtime.ReadMemStats(&after)
+
+	var lines = strings.Split(s, "\n")
+	if len(lines) != 3+2*4000*2 {
+		t.Errorf("\nunexpected diff length: %v lines", len(lines))
+	}
+	if lines[2] != "@@ -1,4000 +1,4000 @@" || lines[3] != "-expected line 0" ||
+		lines[4] != "?^" || lines[8003] != "+value line 0" {
+		t.Errorf("\nunexpected diff:\n%v", strings.Join(lines[:10], "\n"))
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 32<<20 {
+		t.Errorf("\nunexpected memory usage: %v MB", allocated>>20)
+	}
+}
+
+func TestUnifiedLargeInputsWithCommonLines(t *testing.T) {
+	var value, expected strings.Builder
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&value, "line %v\n", i)
+		if i == 5000 {
+			expected.WriteString("changed line\n")
+		} else {
+			fmt.Fprintf(&expected, "line %v\n", i)
+		}
+	}
+	verifyUnified(t, value.String(), expected.String(),
+		"--- expected",
+		"+++ value",
+		"@@ -4998,7 +4998,7 @@",
+		" line 4997",
+		" line 4998",
+		" line 4999",
+		"-changed line",
+		"?^",
+		"+line 5000",
+		"?^",
+		" line 5001",
+		" line 5002",
+		" line 5003",
+	)
+}
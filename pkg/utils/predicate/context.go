@@ -68,7 +68,9 @@ func formatContextValue(w io.Writer, c ContextValue, width int) {
 
 	fmt.Fprintf(w, "%v:%v ", c.Name, padding)
 	if c.Pre {
-		fmt.Fprintf(w, "%v\n", c.Value)
+		var s = fmt.Sprintf("%v", c.Value)
+		s = strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", width+2))
+		fmt.Fprintf(w, "%v\n", s)
 	} else {
 		var formatter = defaultFormatter
 		formatter.NewlineStr = "\n" + strings.Repeat(" ", width+2)
@@ -27,3 +27,21 @@ func TestFormatContextValue(t *testing.T) {
 		t.Errorf("\noutput mismatch\n%v", s)
 	}
 }
+
+func TestFormatContextValueMultilinePre(t *testing.T) {
+
+	var ctx = []predicate.ContextValue{
+		{Name: "expected", Value: "value == x", Pre: true},
+		{Name: "diff", Value: "~ .A: 1 → 2\n+ .B: 3", Pre: true},
+	}
+
+	var s = predicate.FormatContextValues(ctx)
+	var expected = "" +
+		"expected: value == x\n" +
+		"diff:     ~ .A: 1 → 2\n" +
+		"          + .B: 3\n"
+
+	if s != expected {
+		t.Errorf("\noutput mismatch\n%v", s)
+	}
+}
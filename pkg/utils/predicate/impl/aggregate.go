@@ -0,0 +1,340 @@
+package impl
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+// ---------------------------------------------------------------------------
+// Aggregate transformations on collections
+
+// Sum is a transformation that computes the sum of the numeric values of a
+// collection, or of the values of a map, for further evaluation. The result is
+// an int64 if all values are signed integers, an uint64 if all values are
+// unsigned integers, a time.Duration if all values are durations, and a float64
+// otherwise. Integer and duration sums that overflow fail with an error.
+func Sum() (desc string, f predicate.TransformFunc) {
+	desc = "sum({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		values, err := aggregateValues(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, err = sumValues(values)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "sum", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Min is a transformation that extracts the smallest value of a non-empty
+// collection, or of the values of a map, for further evaluation. All values
+// must be order comparable with each other.
+func Min() (desc string, f predicate.TransformFunc) {
+	desc = "min({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = extremeValue(v, "min", -1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "min", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Max is a transformation that extracts the largest value of a non-empty
+// collection, or of the values of a map, for further evaluation. All values
+// must be order comparable with each other.
+func Max() (desc string, f predicate.TransformFunc) {
+	desc = "max({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = extremeValue(v, "max", 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "max", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Mean is a transformation that computes the arithmetic mean of the numeric
+// values of a non-empty collection, or of the values of a map, for further
+// evaluation. The result is a time.Duration if all values are durations, and a
+// float64 otherwise.
+func Mean() (desc string, f predicate.TransformFunc) {
+	desc = "mean({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		floats, durations, err := aggregateFloats(v, "mean")
+		if err != nil {
+			return nil, nil, err
+		}
+		r = floatResult(mean(floats), durations)
+		return r, []predicate.ContextValue{
+			{Name: "mean", Value: r},
+		}, nil
+	}
+	return
+}
+
+// StdDev is a transformation that computes the population standard deviation
+// of the numeric values of a non-empty collection, or of the values of a map,
+// for further evaluation. The result is a time.Duration if all values are
+// durations, and a float64 otherwise.
+func StdDev() (desc string, f predicate.TransformFunc) {
+	desc = "stddev({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		floats, durations, err := aggregateFloats(v, "standard deviation")
+		if err != nil {
+			return nil, nil, err
+		}
+		m := mean(floats)
+		var sum float64
+		for _, x := range floats {
+			sum += (x - m) * (x - m)
+		}
+		r = floatResult(math.Sqrt(sum/float64(len(floats))), durations)
+		return r, []predicate.ContextValue{
+			{Name: "stddev", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Percentile is a transformation that computes the p-th percentile (0 to 100)
+// of the numeric values of a non-empty collection, or of the values of a map,
+// for further evaluation, interpolating linearly between the closest ranks.
+// The result is a time.Duration if all values are durations, and a float64
+// otherwise.
+func Percentile(p float64) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("p%v({})", p)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		if p < 0 || p > 100 || math.IsNaN(p) {
+			return nil, nil, fmt.Errorf("percentile %v is not between 0 and 100", p)
+		}
+		floats, durations, err := aggregateFloats(v, "percentile")
+		if err != nil {
+			return nil, nil, err
+		}
+		sort.Float64s(floats)
+		rank := p / 100 * float64(len(floats)-1)
+		lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+		x := floats[lo] + (floats[hi]-floats[lo])*(rank-float64(lo))
+		r = floatResult(x, durations)
+		return r, []predicate.ContextValue{
+			{Name: fmt.Sprintf("p%v", p), Value: r},
+		}, nil
+	}
+	return
+}
+
+// CountWhere is a transformation that counts the values of a collection that
+// match the given predicate, for further evaluation.
+func CountWhere(p *predicate.Predicate) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("|{x ∈ {} : %v}|", p.FormatDescription("x"))
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		count := 0
+		for i := 0; i < vv.Len(); i++ {
+			success, subctx := p.Evaluate(vv.Index(i).Interface())
+			if _, err := evaluationError(subctx); err != nil {
+				return nil, nil, fmt.Errorf("value at index %v cannot be evaluated: %w", i, err)
+			}
+			if success {
+				count++
+			}
+		}
+		return count, []predicate.ContextValue{
+			{Name: "count", Value: count},
+		}, nil
+	}
+	return
+}
+
+// Aggregate transformations on collections
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for aggregate transformations
+
+// aggregateValues returns the values of a collection, or of a map, or the
+// second values of an iter.Seq2.
+func aggregateValues(v interface{}) ([]interface{}, error) {
+	if reflect.ValueOf(v).Kind() == reflect.Map {
+		if vv, err := extractMapValues(v); err == nil {
+			v = vv
+		}
+	}
+	vv, err := collectionValue(v)
+	if err != nil {
+		return nil, err
+	}
+	var values = make([]interface{}, 0, vv.Len())
+	for i := 0; i < vv.Len(); i++ {
+		e := vv.Index(i).Interface()
+		if kv, ok := e.(value.KeyValue); ok {
+			e = kv.Value
+		}
+		values = append(values, e)
+	}
+	return values, nil
+}
+
+// aggregateFloats returns the values of a non-empty collection converted to
+// float64, and whether they were all durations.
+func aggregateFloats(v interface{}, name string) (floats []float64, durations bool, err error) {
+	values, err := aggregateValues(v)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(values) == 0 {
+		return nil, false, fmt.Errorf("cannot compute the %v of an empty collection", name)
+	}
+	durations = true
+	for i, e := range values {
+		if d, ok := e.(time.Duration); ok {
+			floats = append(floats, float64(d))
+			continue
+		}
+		durations = false
+		x, ok := value.AsFloat(e)
+		if !ok {
+			return nil, false, notNumericError(e, i)
+		}
+		floats = append(floats, x)
+	}
+	return floats, durations, nil
+}
+
+func sumValues(values []interface{}) (interface{}, error) {
+	var ints, uints, durations = true, true, true
+	for i, e := range values {
+		_, isInt := value.AsInt(e)
+		_, isUInt := value.AsUInt(e)
+		_, isFloat := value.AsFloat(e)
+		_, isDuration := e.(time.Duration)
+		if !isInt && !isUInt && !isFloat && !isDuration {
+			return nil, notNumericError(e, i)
+		}
+		ints = ints && isInt
+		uints = uints && isUInt
+		durations = durations && isDuration
+	}
+
+	switch {
+	case len(values) == 0:
+		return 0, nil
+	case durations:
+		var sum time.Duration
+		for i, e := range values {
+			x, ok := addInt64(int64(sum), int64(e.(time.Duration)))
+			if !ok {
+				return nil, overflowError(sum, i)
+			}
+			sum = time.Duration(x)
+		}
+		return sum, nil
+	case ints:
+		var sum int64
+		for i, e := range values {
+			x, _ := value.AsInt(e)
+			var ok bool
+			if sum, ok = addInt64(sum, x); !ok {
+				return nil, overflowError(sum, i)
+			}
+		}
+		return sum, nil
+	case uints:
+		var sum uint64
+		for i, e := range values {
+			x, _ := value.AsUInt(e)
+			var carry uint64
+			if sum, carry = bits.Add64(sum, x, 0); carry != 0 {
+				return nil, overflowError(sum, i)
+			}
+		}
+		return sum, nil
+	}
+
+	var sum float64
+	for i, e := range values {
+		x, ok := value.AsFloat(e)
+		if !ok {
+			return nil, notNumericError(e, i)
+		}
+		sum += x
+	}
+	return sum, nil
+}
+
+// extremeValue returns the value of a non-empty collection that compares as
+// `order` with respect to all the other ones.
+func extremeValue(v interface{}, name string, order int) (interface{}, error) {
+	values, err := aggregateValues(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("cannot compute the %v of an empty collection", name)
+	}
+	r := values[0]
+	for i, e := range values[1:] {
+		o, err := value.CompareOrdered(e, r)
+		if err != nil {
+			return nil, fmt.Errorf("value at index %v cannot be compared: %w", i+1, err)
+		}
+		if o == order {
+			r = e
+		}
+	}
+	return r, nil
+}
+
+func mean(floats []float64) float64 {
+	var sum float64
+	for _, x := range floats {
+		sum += x
+	}
+	return sum / float64(len(floats))
+}
+
+func floatResult(x float64, durations bool) interface{} {
+	if durations {
+		return time.Duration(math.Round(x))
+	}
+	return x
+}
+
+func notNumericError(v interface{}, index int) error {
+	return fmt.Errorf("value of type '%T' at index %v is not numeric", v, index)
+}
+
+// addInt64 returns the sum of two int64 values, and false if it overflows.
+func addInt64(a, b int64) (int64, bool) {
+	var sum = a + b
+	return sum, (sum > a) == (b > 0)
+}
+
+func overflowError(sum interface{}, index int) error {
+	return fmt.Errorf("sum of values of type '%T' overflows at index %v", sum, index)
+}
+
+// Helper functions for aggregate transformations
+// ---------------------------------------------------------------------------
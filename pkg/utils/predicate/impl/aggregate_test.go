@@ -0,0 +1,123 @@
+package impl_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+)
+
+func TestSum(t *testing.T) {
+	verifyTransform(t, tr(impl.Sum()), expectation{value: []int{1, 2, 3}, result: int64(6)})
+	verifyTransform(t, tr(impl.Sum()), expectation{value: []uint64{math.MaxUint64 - 1, 1}, result: uint64(math.MaxUint64)})
+	verifyTransform(t, tr(impl.Sum()), expectation{value: []interface{}{1, 2.5}, result: 3.5})
+	verifyTransform(t, tr(impl.Sum()), expectation{value: map[string]int{"a": 1, "b": 2}, result: int64(3)})
+	verifyTransform(t, tr(impl.Sum()), expectation{value: slices.Values([]float32{0.5, 0.25}), result: 0.75})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:  []time.Duration{time.Second, time.Millisecond},
+		result: 1001 * time.Millisecond,
+	})
+	verifyTransform(t, tr(impl.Sum()), expectation{value: []int{}, result: 0})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:    []interface{}{1, "2"},
+		errorMsg: "value of type 'string' at index 1 is not numeric",
+	})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:    []int64{math.MaxInt64 - 1, 1, 1},
+		errorMsg: "sum of values of type 'int64' overflows at index 2",
+	})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:    []int64{math.MinInt64 + 1, -2},
+		errorMsg: "sum of values of type 'int64' overflows at index 1",
+	})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:    []uint64{math.MaxUint64, 1},
+		errorMsg: "sum of values of type 'uint64' overflows at index 1",
+	})
+	verifyTransform(t, tr(impl.Sum()), expectation{
+		value:    []time.Duration{math.MaxInt64, time.Second},
+		errorMsg: "sum of values of type 'time.Duration' overflows at index 1",
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	verifyTransform(t, tr(impl.Min()), expectation{value: []int{3, 1, 2}, result: 1})
+	verifyTransform(t, tr(impl.Max()), expectation{value: []int{3, 1, 2}, result: 3})
+	verifyTransform(t, tr(impl.Max()), expectation{value: []string{"b", "c", "a"}, result: "c"})
+	verifyTransform(t, tr(impl.Min()), expectation{value: map[string]float64{"a": 1.5, "b": 0.5}, result: 0.5})
+	verifyTransform(t, tr(impl.Min()), expectation{
+		value:    []int{},
+		errorMsg: "cannot compute the min of an empty collection",
+	})
+	verifyTransform(t, tr(impl.Max()), expectation{
+		value:    []interface{}{1, "a"},
+		errorMsg: "value at index 1 cannot be compared: values of type 'string' and 'int' are not order comparable",
+	})
+}
+
+func TestMean(t *testing.T) {
+	verifyTransform(t, tr(impl.Mean()), expectation{value: []int{1, 2, 3, 4}, result: 2.5})
+	verifyTransform(t, tr(impl.Mean()), expectation{
+		value:  []time.Duration{time.Second, 2 * time.Second},
+		result: 1500 * time.Millisecond,
+	})
+	verifyTransform(t, tr(impl.Mean()), expectation{
+		value:    []float64{},
+		errorMsg: "cannot compute the mean of an empty collection",
+	})
+	verifyTransform(t, tr(impl.Mean()), expectation{
+		value:    []string{"a"},
+		errorMsg: "value of type 'string' at index 0 is not numeric",
+	})
+}
+
+func TestStdDev(t *testing.T) {
+	verifyTransform(t, tr(impl.StdDev()), expectation{value: []int{2, 4, 4, 4, 5, 5, 7, 9}, result: 2.0})
+	verifyTransform(t, tr(impl.StdDev()), expectation{value: []float64{1}, result: 0.0})
+}
+
+func TestPercentile(t *testing.T) {
+	var values = []int{5, 1, 4, 2, 3}
+	verifyTransform(t, tr(impl.Percentile(0)), expectation{value: values, result: 1.0})
+	verifyTransform(t, tr(impl.Percentile(50)), expectation{value: values, result: 3.0})
+	verifyTransform(t, tr(impl.Percentile(100)), expectation{value: values, result: 5.0})
+	verifyTransform(t, tr(impl.Percentile(90)), expectation{value: values, result: 4.6})
+	verifyTransform(t, tr(impl.Percentile(99)), expectation{
+		value:  []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		result: 19900 * time.Microsecond,
+	})
+	verifyTransform(t, tr(impl.Percentile(101)), expectation{
+		value:    values,
+		errorMsg: "percentile 101 is not between 0 and 100",
+	})
+	verifyTransform(t, tr(impl.Percentile(50)), expectation{
+		value:    []int{},
+		errorMsg: "cannot compute the percentile of an empty collection",
+	})
+}
+
+func TestCountWhere(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	verifyTransform(t, tr(impl.CountWhere(p)), expectation{value: []int{1, 5, 2, 3}, result: 2})
+	verifyTransform(t, tr(impl.CountWhere(p)), expectation{value: []int{}, result: 0})
+	verifyTransform(t, tr(impl.CountWhere(p)), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+	verifyTransform(t, tr(impl.CountWhere(p)), expectation{
+		value:    []interface{}{1, "a", 2},
+		errorMsg: "value at index 1 cannot be evaluated: values of type 'string' and 'int' are not order comparable",
+	})
+	if desc, _ := impl.CountWhere(p); desc != "|{x ∈ {} : x < 3}|" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+}
@@ -3,29 +3,27 @@ package impl
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 )
 
 // All tests if all values of a collection match the given predicate
 func All(p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("∀ x ∈ value, %v", p.FormatDescription("x"))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
-		vv := reflect.ValueOf(v)
-		switch vv.Kind() {
-		case reflect.Array, reflect.Slice:
-			for i := 0; i < vv.Len(); i++ {
-				success, ctx := p.Evaluate(vv.Index(i).Interface())
-				if !success {
-					return false, updateContext(ctx, i), nil
-				}
+		vv, err := collectionValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := 0; i < vv.Len(); i++ {
+			success, ctx := p.Evaluate(vv.Index(i).Interface())
+			if !success {
+				return false, updateContext(ctx, i), nil
 			}
-
-		default:
-			return false, nil, fmt.Errorf(
-				"value of type '%v' is not a collection",
-				vv.Type())
 		}
 		return true, nil, nil
 	}
@@ -36,29 +34,626 @@ func All(p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
 func Any(p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("∃ x ∈ value, %v", p.FormatDescription("x"))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
-		vv := reflect.ValueOf(v)
-		switch vv.Kind() {
-		case reflect.Array, reflect.Slice:
-			for i := 0; i < vv.Len(); i++ {
-				success, subctx := p.Evaluate(vv.Index(i).Interface())
-				if success {
-					return true, nil, nil
+		vv, err := collectionValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := 0; i < vv.Len(); i++ {
+			success, subctx := p.Evaluate(vv.Index(i).Interface())
+			if success {
+				return true, nil, nil
+			}
+			if len(ctx) == 0 {
+				ctx = updateContext(subctx, i)
+			}
+		}
+		return false, ctx, nil
+	}
+	return
+}
+
+// Collect is a transformation that materializes the elements of an iterable
+// value into a slice for further evaluation. An `iter.Seq[T]` and a channel of
+// T produce a `[]T`, while an `iter.Seq2` and a map produce a
+// `[]value.KeyValue`, with map entries ordered by key. Channels are drained
+// until closed, or until no new element is received within
+// `value.ChannelTimeout`.
+func Collect() (desc string, f predicate.TransformFunc) {
+	desc = "collect({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = value.Collect(v)
+		ctx = []predicate.ContextValue{
+			{Name: "collected", Value: r},
+		}
+		if err != nil {
+			if r == nil {
+				ctx = nil
+			}
+			return nil, ctx, err
+		}
+		return r, ctx, nil
+	}
+	return
+}
+
+// None tests if no value of a collection matches the given predicate
+func None(p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("∄ x ∈ value, %v", p.FormatDescription("x"))
+	f = countPredicate(p, func(count int) (bool, bool) {
+		return count == 0, true
+	})
+	return
+}
+
+// Exactly tests if exactly n values of a collection match the given predicate
+func Exactly(n int, p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("|{x ∈ value : %v}| == %v", p.FormatDescription("x"), n)
+	f = countPredicate(p, func(count int) (bool, bool) {
+		return count == n, count > n
+	})
+	return
+}
+
+// AtLeast tests if at least n values of a collection match the given predicate
+func AtLeast(n int, p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("|{x ∈ value : %v}| >= %v", p.FormatDescription("x"), n)
+	f = countPredicate(p, func(count int) (bool, bool) {
+		return count >= n, false
+	})
+	return
+}
+
+// AtMost tests if at most n values of a collection match the given predicate
+func AtMost(n int, p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("|{x ∈ value : %v}| <= %v", p.FormatDescription("x"), n)
+	f = countPredicate(p, func(count int) (bool, bool) {
+		return count <= n, true
+	})
+	return
+}
+
+// ---------------------------------------------------------------------------
+// Ordering predicates on collections
+
+// IsSorted tests if the values of a collection are in ascending order, allowing
+// for consecutive equal values.
+func IsSorted() (desc string, f predicate.PredicateFunc) {
+	desc = "{} is sorted"
+	f = orderPredicate("", func(order int) bool { return order <= 0 })
+	return
+}
+
+// IsSortedBy tests if the values of a collection are in ascending order of the
+// value identified by `keypath`, allowing for consecutive equal keys.
+func IsSortedBy(keypath string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} is sorted by %v", keypath)
+	f = orderPredicate(keypath, func(order int) bool { return order <= 0 })
+	return
+}
+
+// IsStrictlyIncreasing tests if the values of a collection are in strictly
+// ascending order, without consecutive equal values.
+func IsStrictlyIncreasing() (desc string, f predicate.PredicateFunc) {
+	desc = "{} is strictly increasing"
+	f = orderPredicate("", func(order int) bool { return order < 0 })
+	return
+}
+
+// IsDescending tests if the values of a collection are in descending order,
+// allowing for consecutive equal values.
+func IsDescending() (desc string, f predicate.PredicateFunc) {
+	desc = "{} is descending"
+	f = orderPredicate("", func(order int) bool { return order >= 0 })
+	return
+}
+
+// HasNoDuplicates tests if all the values of a collection are distinct.
+func HasNoDuplicates() (desc string, f predicate.PredicateFunc) {
+	desc = "{} has no duplicates"
+	f = duplicatesPredicate("")
+	return
+}
+
+// HasNoDuplicatesBy tests if the values identified by `keypath` are distinct
+// for all the values of a collection.
+func HasNoDuplicatesBy(keypath string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} has no duplicates by %v", keypath)
+	f = duplicatesPredicate(keypath)
+	return
+}
+
+// Ordering predicates on collections
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for ordering predicates
+
+// collectionKeys returns the values of a collection, or the values identified
+// by `keypath` for each value of a collection if `keypath` is not empty.
+func collectionKeys(v interface{}, keypath string) ([]interface{}, error) {
+	vv, err := collectionValue(v)
+	if err != nil {
+		return nil, err
+	}
+	var keys = make([]interface{}, 0, vv.Len())
+	for i := 0; i < vv.Len(); i++ {
+		e := vv.Index(i).Interface()
+		if keypath != "" {
+			e = value.Field(e, keypath)
+		}
+		keys = append(keys, e)
+	}
+	return keys, nil
+}
+
+func keyName(index int, keypath string) string {
+	if keypath != "" {
+		return fmt.Sprintf("$[%v].%v", index, keypath)
+	}
+	return fmt.Sprintf("$[%v]", index)
+}
+
+// orderPredicate returns a predicate function that checks that the order of
+// each pair of consecutive keys is accepted, and reports the first offending
+// pair otherwise.
+func orderPredicate(keypath string, accept func(order int) bool) predicate.PredicateFunc {
+	return func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		keys, err := collectionKeys(v, keypath)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := 1; i < len(keys); i++ {
+			order, err := value.CompareOrdered(keys[i-1], keys[i])
+			if err != nil {
+				return false, nil, fmt.Errorf(
+					"values at index %v and %v cannot be compared: %w", i-1, i, err)
+			}
+			if !accept(order) {
+				return false, []predicate.ContextValue{
+					{Name: keyName(i-1, keypath), Value: keys[i-1]},
+					{Name: keyName(i, keypath), Value: keys[i]},
+				}, nil
+			}
+		}
+		return true, nil, nil
+	}
+}
+
+// duplicatesPredicate returns a predicate function that checks that all keys
+// are distinct, and reports the indexes of the duplicated ones otherwise.
+func duplicatesPredicate(keypath string) predicate.PredicateFunc {
+	return func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		keys, err := collectionKeys(v, keypath)
+		if err != nil {
+			return false, nil, err
+		}
+
+		var lines []string
+		var count int
+		for _, g := range value.IndexGroups(keys) {
+			if len(g) < 2 {
+				continue
+			}
+			count++
+			if len(lines) < 10 {
+				lines = append(lines, fmt.Sprintf("%v at indexes %v",
+					prettyprint.FormatValue(keys[g[0]]), formatIndexes(g)))
+			}
+		}
+		if count == 0 {
+			return true, nil, nil
+		}
+		if count > len(lines) {
+			lines = append(lines, fmt.Sprintf("... (%v more)", count-len(lines)))
+		}
+		return false, []predicate.ContextValue{
+			{Name: "duplicates", Value: strings.Join(lines, "\n"), Pre: true},
+		}, nil
+	}
+}
+
+// Helper functions for ordering predicates
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Transformations on collections
+
+// Where is a transformation that filters the values of a collection, keeping
+// only the ones that match the given predicate, for further evaluation.
+func Where(p *predicate.Predicate) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("{}.Where(%v)", p.FormatDescription("x"))
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		rv := reflect.MakeSlice(reflect.SliceOf(vv.Type().Elem()), 0, 0)
+		for i := 0; i < vv.Len(); i++ {
+			success, subctx := p.Evaluate(vv.Index(i).Interface())
+			if _, err := evaluationError(subctx); err != nil {
+				return nil, nil, fmt.Errorf("value at index %v cannot be evaluated: %w", i, err)
+			}
+			if success {
+				rv = reflect.Append(rv, vv.Index(i))
+			}
+		}
+		r = rv.Interface()
+		return r, []predicate.ContextValue{
+			{Name: "filtered", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Select is a transformation that extracts the value identified by `keypath`
+// from each value of a collection, for further evaluation. See value.Field()
+// for more details about keypaths.
+func Select(keypath string) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("{}.Select(%v)", keypath)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		var selected = make([]interface{}, 0, vv.Len())
+		for i := 0; i < vv.Len(); i++ {
+			selected = append(selected, value.Field(vv.Index(i).Interface(), keypath))
+		}
+		return selected, []predicate.ContextValue{
+			{Name: fmt.Sprintf("$[*].%v", keypath), Value: selected},
+		}, nil
+	}
+	return
+}
+
+// Sorted is a transformation that sorts the values of a collection in
+// ascending order, for further evaluation. All values must be order comparable
+// with each other.
+func Sorted() (desc string, f predicate.TransformFunc) {
+	desc = "{}.Sorted()"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = sortCollection(v, func(e interface{}) interface{} { return e })
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "sorted", Value: r},
+		}, nil
+	}
+	return
+}
+
+// SortBy is a transformation that sorts the values of a collection in
+// ascending order of the value identified by `keypath`, for further
+// evaluation. The sort is stable, preserving the relative order of values with
+// equal keys.
+func SortBy(keypath string) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("{}.SortBy(%v)", keypath)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = sortCollection(v, func(e interface{}) interface{} {
+			return value.Field(e, keypath)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "sorted", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Reverse is a transformation that reverses the order of the values of a
+// collection, for further evaluation.
+func Reverse() (desc string, f predicate.TransformFunc) {
+	desc = "{}.Reverse()"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		n := vv.Len()
+		rv := reflect.MakeSlice(reflect.SliceOf(vv.Type().Elem()), n, n)
+		for i := 0; i < n; i++ {
+			rv.Index(i).Set(vv.Index(n - 1 - i))
+		}
+		r = rv.Interface()
+		return r, []predicate.ContextValue{
+			{Name: "reversed", Value: r},
+		}, nil
+	}
+	return
+}
+
+// GroupBy is a transformation that groups the values of a collection by the
+// value identified by `keypath`, for further evaluation. The result is a map
+// from each distinct key to the slice of values sharing that key, in their
+// original order.
+func GroupBy(keypath string) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("{}.GroupBy(%v)", keypath)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		sliceType := reflect.SliceOf(vv.Type().Elem())
+		keyType := reflect.TypeOf((*interface{})(nil)).Elem()
+		groups := reflect.MakeMap(reflect.MapOf(keyType, sliceType))
+		for i := 0; i < vv.Len(); i++ {
+			e := vv.Index(i)
+			k := value.Field(e.Interface(), keypath)
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				return nil, nil, fmt.Errorf(
+					"value of type '%T' at index %v cannot be used as a group key", k, i)
+			}
+			kv := reflect.ValueOf(&k).Elem()
+			g := groups.MapIndex(kv)
+			if !g.IsValid() {
+				g = reflect.MakeSlice(sliceType, 0, 1)
+			}
+			groups.SetMapIndex(kv, reflect.Append(g, e))
+		}
+		r = groups.Interface()
+		return r, []predicate.ContextValue{
+			{Name: "groups", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Flatten is a transformation that concatenates the nested collections of a
+// collection of collections, for further evaluation. Only one level of nesting
+// is removed, and values that are not collections are kept as is.
+func Flatten() (desc string, f predicate.TransformFunc) {
+	desc = "{}.Flatten()"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		elemType := reflect.TypeOf((*interface{})(nil)).Elem()
+		if k := vv.Type().Elem().Kind(); k == reflect.Slice || k == reflect.Array {
+			elemType = vv.Type().Elem().Elem()
+		}
+		rv := reflect.MakeSlice(reflect.SliceOf(elemType), 0, vv.Len())
+		for i := 0; i < vv.Len(); i++ {
+			e := vv.Index(i)
+			if e.Kind() == reflect.Interface {
+				e = e.Elem()
+			}
+			if k := e.Kind(); k == reflect.Slice || k == reflect.Array {
+				for j := 0; j < e.Len(); j++ {
+					rv = reflect.Append(rv, e.Index(j))
 				}
-				if len(ctx) == 0 {
-					ctx = updateContext(subctx, i)
+			} else if e.IsValid() {
+				rv = reflect.Append(rv, e)
+			} else {
+				rv = reflect.Append(rv, reflect.Zero(elemType))
+			}
+		}
+		r = rv.Interface()
+		return r, []predicate.ContextValue{
+			{Name: "flattened", Value: r},
+		}, nil
+	}
+	return
+}
+
+// First is a transformation that extracts the first value of a non-empty
+// collection for further evaluation.
+func First() (desc string, f predicate.TransformFunc) {
+	desc = "{}.First()"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = collectionElement(v, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "first", Value: r},
+		}, nil
+	}
+	return
+}
+
+// Last is a transformation that extracts the last value of a non-empty
+// collection for further evaluation.
+func Last() (desc string, f predicate.TransformFunc) {
+	desc = "{}.Last()"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = collectionElement(v, -1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "last", Value: r},
+		}, nil
+	}
+	return
+}
+
+// At is a transformation that extracts the value at index `i` of a collection
+// for further evaluation. Negative indexes count from the end of the
+// collection.
+func At(i int) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("{}[%v]", i)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = collectionElement(v, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: fmt.Sprintf("$[%v]", i), Value: r},
+		}, nil
+	}
+	return
+}
+
+// Transformations on collections
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for collection transformations
+
+// sortCollection returns a sorted copy of a collection, using the given
+// function to extract the sort key of each value.
+func sortCollection(v interface{}, key func(e interface{}) interface{}) (interface{}, error) {
+	vv, err := collectionValue(v)
+	if err != nil {
+		return nil, err
+	}
+	n := vv.Len()
+	keys := make([]interface{}, n)
+	indexes := make([]int, n)
+	for i := 0; i < n; i++ {
+		keys[i] = key(vv.Index(i).Interface())
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		a, b := indexes[i], indexes[j]
+		order, cmpErr := value.CompareOrdered(keys[a], keys[b])
+		if cmpErr != nil {
+			err = fmt.Errorf("values at index %v and %v cannot be sorted: %w", a, b, cmpErr)
+		}
+		return order < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.MakeSlice(reflect.SliceOf(vv.Type().Elem()), n, n)
+	for i, index := range indexes {
+		rv.Index(i).Set(vv.Index(index))
+	}
+	return rv.Interface(), nil
+}
+
+// collectionElement returns the value at a given index of a collection, with
+// negative indexes counting from the end of the collection.
+func collectionElement(v interface{}, i int) (interface{}, error) {
+	vv, err := collectionValue(v)
+	if err != nil {
+		return nil, err
+	}
+	n := vv.Len()
+	if n == 0 {
+		return nil, fmt.Errorf("collection of type '%T' is empty", v)
+	}
+	index := i
+	if index < 0 {
+		index += n
+	}
+	if index < 0 || index >= n {
+		return nil, fmt.Errorf("index %v out of range for collection of length %v", i, n)
+	}
+	return vv.Index(index).Interface(), nil
+}
+
+// Helper functions for collection transformations
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for collection quantifiers
+
+// collectionValue returns the reflected value of a collection, first
+// materializing the elements of iterators, maps and channels into a slice.
+func collectionValue(v interface{}) (reflect.Value, error) {
+	if value.IsIterable(v) {
+		c, err := value.Collect(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = c
+	}
+	vv := reflect.ValueOf(v)
+	switch vv.Kind() {
+	case reflect.Array, reflect.Slice:
+		return vv, nil
+	}
+	return reflect.Value{}, fmt.Errorf("value of type '%T' is not a collection", v)
+}
+
+// countPredicate returns a predicate function that evaluates the sub-predicate
+// on every value of a collection and passes the number of matching values to
+// `check`, which returns whether the predicate passes and, in case of failure,
+// whether the matching values rather than the non-matching values are at
+// fault. On failure, the context lists the indexes of the offending values
+// along with the context of the first one.
+func countPredicate(p *predicate.Predicate, check func(count int) (r, tooMany bool)) predicate.PredicateFunc {
+	return func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		vv, err := collectionValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+
+		var matching, nonMatching []int
+		var matchingCtx, nonMatchingCtx []predicate.ContextValue
+		for i := 0; i < vv.Len(); i++ {
+			success, subctx := p.Evaluate(vv.Index(i).Interface())
+			if _, err := evaluationError(subctx); err != nil {
+				return false, nil, fmt.Errorf("value at index %v cannot be evaluated: %w", i, err)
+			}
+			if success {
+				if len(matching) == 0 {
+					matchingCtx = updateContext(subctx, i)
+				}
+				matching = append(matching, i)
+			} else {
+				if len(nonMatching) == 0 {
+					nonMatchingCtx = updateContext(subctx, i)
 				}
+				nonMatching = append(nonMatching, i)
 			}
+		}
 
-		default:
-			return false, nil, fmt.Errorf(
-				"value of type '%v' is not a collection",
-				vv.Type())
+		r, tooMany := check(len(matching))
+		if r {
+			return true, nil, nil
+		}
+		ctx = []predicate.ContextValue{
+			{Name: "matches", Value: len(matching)},
+		}
+		if tooMany {
+			ctx = append(ctx, predicate.ContextValue{
+				Name: "matching indexes", Value: formatIndexes(matching), Pre: true,
+			})
+			ctx = append(ctx, matchingCtx...)
+		} else {
+			ctx = append(ctx, predicate.ContextValue{
+				Name: "non-matching indexes", Value: formatIndexes(nonMatching), Pre: true,
+			})
+			ctx = append(ctx, nonMatchingCtx...)
 		}
 		return false, ctx, nil
 	}
-	return
 }
 
+// formatIndexes returns a comma-separated list of indexes, abbreviated past
+// the first 20 entries.
+func formatIndexes(indexes []int) string {
+	if len(indexes) == 0 {
+		return "none"
+	}
+	var buf strings.Builder
+	for i, index := range indexes {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if i >= 20 {
+			fmt.Fprintf(&buf, "... (%v more)", len(indexes)-i)
+			break
+		}
+		fmt.Fprintf(&buf, "%v", index)
+	}
+	return buf.String()
+}
+
+// Helper functions for collection quantifiers
+// ---------------------------------------------------------------------------
+
 func updateContext(
 	ctx []predicate.ContextValue, index int) (
 	result []predicate.ContextValue) {
@@ -1,10 +1,15 @@
 package impl_test
 
 import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 )
 
 func TestAll(t *testing.T) {
@@ -40,3 +45,368 @@ func TestAnyNested(t *testing.T) {
 
 	verifyPredicate(t, pr(impl.Any(p1)), expectation{value: [][]int{{3, 4, 5}}, pass: false})
 }
+
+func TestNone(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	verifyPredicate(t, pr(impl.None(p)), expectation{value: []int{3, 4, 5}, pass: true})
+	verifyPredicate(t, pr(impl.None(p)), expectation{value: []int{3, 4, 2, 5}, pass: false})
+	verifyPredicate(t, pr(impl.None(p)), expectation{
+		value:    2,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+}
+
+func TestExactly(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	verifyPredicate(t, pr(impl.Exactly(2, p)), expectation{value: []int{1, 4, 2}, pass: true})
+	verifyPredicate(t, pr(impl.Exactly(2, p)), expectation{value: []int{1, 4, 5}, pass: false})
+	verifyPredicate(t, pr(impl.Exactly(2, p)), expectation{value: []int{1, 2, 0}, pass: false})
+	verifyPredicate(t, pr(impl.Exactly(0, p)), expectation{value: []int{}, pass: true})
+	verifyPredicate(t, pr(impl.Exactly(2, p)), expectation{
+		value:    2,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+}
+
+func TestAtLeast(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	verifyPredicate(t, pr(impl.AtLeast(2, p)), expectation{value: []int{1, 4, 2}, pass: true})
+	verifyPredicate(t, pr(impl.AtLeast(2, p)), expectation{value: []int{1, 2, 0}, pass: true})
+	verifyPredicate(t, pr(impl.AtLeast(2, p)), expectation{value: []int{1, 4, 5}, pass: false})
+}
+
+func TestAtMost(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	verifyPredicate(t, pr(impl.AtMost(2, p)), expectation{value: []int{1, 4, 2}, pass: true})
+	verifyPredicate(t, pr(impl.AtMost(2, p)), expectation{value: []int{4, 5}, pass: true})
+	verifyPredicate(t, pr(impl.AtMost(2, p)), expectation{value: []int{1, 2, 0}, pass: false})
+}
+
+func TestQuantifierErrors(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.StartsWith("x"))
+	const errorMsg = "value at index 0 cannot be evaluated: value of type 'int' is not a sequence"
+
+	verifyPredicate(t, pr(impl.None(p)), expectation{value: []int{1, 2}, errorMsg: errorMsg})
+	verifyPredicate(t, pr(impl.Exactly(0, p)), expectation{value: []int{1, 2}, errorMsg: errorMsg})
+	verifyPredicate(t, pr(impl.AtLeast(0, p)), expectation{value: []int{1, 2}, errorMsg: errorMsg})
+	verifyPredicate(t, pr(impl.AtMost(0, p)), expectation{value: []int{1, 2}, errorMsg: errorMsg})
+}
+
+func TestQuantifierContext(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	_, f := impl.AtMost(1, p)
+	_, ctx, _ := f([]int{5, 1, 2, 6, 0})
+	expected := []predicate.ContextValue{
+		{Name: "matches", Value: 3},
+		{Name: "matching indexes", Value: "1, 2, 4", Pre: true},
+		{Name: "value @(1)", Value: 1},
+	}
+	if !reflect.DeepEqual(ctx, expected) {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+
+	_, f = impl.AtLeast(2, p)
+	_, ctx, _ = f([]int{5, 1, 6})
+	expected = []predicate.ContextValue{
+		{Name: "matches", Value: 1},
+		{Name: "non-matching indexes", Value: "0, 2", Pre: true},
+		{Name: "value @(0)", Value: 5},
+	}
+	if !reflect.DeepEqual(ctx, expected) {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestQuantifierNested(t *testing.T) {
+	var p0 = &predicate.Predicate{}
+	p0.RegisterPredicate(impl.Lt(3))
+
+	var p1 = &predicate.Predicate{}
+	p1.RegisterPredicate(impl.None(p0))
+
+	_, f := impl.All(p1)
+	_, ctx, _ := f([][]int{{3, 4}, {5, 1, 2}})
+	expected := []predicate.ContextValue{
+		{Name: "value @(1)", Value: []int{5, 1, 2}},
+		{Name: "matches @(1)", Value: 2},
+		{Name: "matching indexes @(1)", Value: "1, 2", Pre: true},
+		{Name: "value @(1,1)", Value: 1},
+	}
+	if !reflect.DeepEqual(ctx, expected) {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestCollectionOverIterables(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(impl.Lt(3))
+
+	var ch = make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+
+	verifyPredicate(t, pr(impl.All(p)), expectation{value: slices.Values([]int{1, 2}), pass: true})
+	verifyPredicate(t, pr(impl.All(p)), expectation{value: slices.Values([]int{1, 3}), pass: false})
+	verifyPredicate(t, pr(impl.Any(p)), expectation{value: ch, pass: true})
+	verifyPredicate(t, pr(impl.None(p)), expectation{value: slices.Values([]int{3, 4}), pass: true})
+
+	var kv = &predicate.Predicate{}
+	kv.RegisterTransformation(impl.Field("Value"))
+	kv.RegisterPredicate(impl.Lt(3))
+
+	verifyPredicate(t, pr(impl.All(kv)), expectation{value: map[string]int{"a": 1, "b": 2}, pass: true})
+	verifyPredicate(t, pr(impl.Exactly(1, kv)), expectation{value: maps.All(map[string]int{"a": 1, "b": 3}), pass: true})
+	verifyPredicate(t, pr(impl.All(p)), expectation{
+		value:    iter.Seq[int](nil),
+		errorMsg: "value of type 'iter.Seq[int]' is a nil iterator",
+	})
+}
+
+func TestCollect(t *testing.T) {
+	verifyTransform(t, tr(impl.Collect()), expectation{
+		value:  slices.Values([]string{"a", "b"}),
+		result: []string{"a", "b"},
+	})
+	verifyTransform(t, tr(impl.Collect()), expectation{
+		value:  map[string]int{"b": 2, "a": 1},
+		result: []value.KeyValue{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+	})
+	verifyTransform(t, tr(impl.Collect()), expectation{
+		value:  []int{1, 2},
+		result: []int{1, 2},
+	})
+	verifyTransform(t, tr(impl.Collect()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+}
+
+func TestCollectContextOverLimit(t *testing.T) {
+	defer func(n int) { value.MaxCollectedElements = n }(value.MaxCollectedElements)
+	value.MaxCollectedElements = 2
+
+	var ch = make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	_, f := impl.Collect()
+	_, ctx, err := f(ch)
+	if err == nil || len(ctx) != 1 || ctx[0].Name != "collected" ||
+		!reflect.DeepEqual(ctx[0].Value, []int{1, 2, 3}) {
+		t.Errorf("\nunexpected result: %v, %v", ctx, err)
+	}
+}
+
+type user struct {
+	Name   string
+	Dept   string
+	Age    int
+	Active bool
+}
+
+var users = []user{
+	{"bob", "eng", 32, true},
+	{"alice", "ops", 28, false},
+	{"carol", "eng", 45, true},
+	{"dave", "ops", 28, true},
+}
+
+func TestWhere(t *testing.T) {
+	var p = &predicate.Predicate{}
+	p.RegisterTransformation(impl.Field("Active"))
+	p.RegisterPredicate(impl.Eq(true))
+
+	verifyTransform(t, tr(impl.Where(p)), expectation{
+		value:  users,
+		result: []user{users[0], users[2], users[3]},
+	})
+	verifyTransform(t, tr(impl.Where(p)), expectation{
+		value:  []user{},
+		result: []user{},
+	})
+	verifyTransform(t, tr(impl.Where(p)), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+
+	var q = &predicate.Predicate{}
+	q.RegisterPredicate(impl.Lt(3))
+	verifyTransform(t, tr(impl.Where(q)), expectation{
+		value:    []interface{}{1, "a", 2},
+		errorMsg: "value at index 1 cannot be evaluated: values of type 'string' and 'int' are not order comparable",
+	})
+	if desc, _ := impl.Where(p); desc != "{}.Where(x.Active == true)" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	verifyTransform(t, tr(impl.Select("Name")), expectation{
+		value:  users,
+		result: []interface{}{"bob", "alice", "carol", "dave"},
+	})
+	verifyTransform(t, tr(impl.Select("Name")), expectation{
+		value:    "abc",
+		errorMsg: "value of type 'string' is not a collection",
+	})
+}
+
+func TestSorted(t *testing.T) {
+	verifyTransform(t, tr(impl.Sorted()), expectation{
+		value:  []int{3, 1, 2},
+		result: []int{1, 2, 3},
+	})
+	verifyTransform(t, tr(impl.Sorted()), expectation{
+		value:  slices.Values([]string{"b", "c", "a"}),
+		result: []string{"a", "b", "c"},
+	})
+	verifyTransform(t, tr(impl.Sorted()), expectation{
+		value:    []interface{}{1, "a"},
+		errorMsg: "values at index 1 and 0 cannot be sorted: values of type 'string' and 'int' are not order comparable",
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	verifyTransform(t, tr(impl.SortBy("Age")), expectation{
+		value:  users,
+		result: []user{users[1], users[3], users[0], users[2]},
+	})
+}
+
+func TestReverse(t *testing.T) {
+	verifyTransform(t, tr(impl.Reverse()), expectation{
+		value:  []int{1, 2, 3},
+		result: []int{3, 2, 1},
+	})
+	verifyTransform(t, tr(impl.Reverse()), expectation{
+		value:  [0]int{},
+		result: []int{},
+	})
+}
+
+func TestGroupBy(t *testing.T) {
+	verifyTransform(t, tr(impl.GroupBy("Dept")), expectation{
+		value: users,
+		result: map[interface{}][]user{
+			"eng": {users[0], users[2]},
+			"ops": {users[1], users[3]},
+		},
+	})
+	verifyTransform(t, tr(impl.GroupBy("Tags")), expectation{
+		value:    []struct{ Tags []string }{{[]string{"a"}}},
+		errorMsg: "value of type '[]string' at index 0 cannot be used as a group key",
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	verifyTransform(t, tr(impl.Flatten()), expectation{
+		value:  [][]int{{1, 2}, {}, {3}},
+		result: []int{1, 2, 3},
+	})
+	verifyTransform(t, tr(impl.Flatten()), expectation{
+		value:  []interface{}{[]int{1}, "a", nil, []string{"b"}},
+		result: []interface{}{1, "a", nil, "b"},
+	})
+}
+
+func TestFirstLastAt(t *testing.T) {
+	verifyTransform(t, tr(impl.First()), expectation{value: []int{1, 2, 3}, result: 1})
+	verifyTransform(t, tr(impl.Last()), expectation{value: []int{1, 2, 3}, result: 3})
+	verifyTransform(t, tr(impl.At(1)), expectation{value: []int{1, 2, 3}, result: 2})
+	verifyTransform(t, tr(impl.At(-3)), expectation{value: []int{1, 2, 3}, result: 1})
+	verifyTransform(t, tr(impl.First()), expectation{
+		value:    []int{},
+		errorMsg: "collection of type '[]int' is empty",
+	})
+	verifyTransform(t, tr(impl.At(3)), expectation{
+		value:    []int{1, 2, 3},
+		errorMsg: "index 3 out of range for collection of length 3",
+	})
+}
+
+func TestIsSorted(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsSorted()), expectation{value: []int{1, 2, 2, 3}, pass: true})
+	verifyPredicate(t, pr(impl.IsSorted()), expectation{value: []int{}, pass: true})
+	verifyPredicate(t, pr(impl.IsSorted()), expectation{value: []int{1, 3, 2}, pass: false})
+	verifyPredicate(t, pr(impl.IsSorted()), expectation{
+		value:    []interface{}{1, "a"},
+		errorMsg: "values at index 0 and 1 cannot be compared: values of type 'int' and 'string' are not order comparable",
+	})
+	verifyPredicate(t, pr(impl.IsSorted()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a collection",
+	})
+
+	_, f := impl.IsSorted()
+	_, ctx, _ := f([]int{1, 3, 2})
+	expected := []predicate.ContextValue{
+		{Name: "$[1]", Value: 3},
+		{Name: "$[2]", Value: 2},
+	}
+	if !reflect.DeepEqual(ctx, expected) {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestIsSortedBy(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsSortedBy("Name")), expectation{
+		value: []user{users[1], users[0], users[2]},
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsSortedBy("Age")), expectation{value: users, pass: false})
+
+	_, f := impl.IsSortedBy("Age")
+	_, ctx, _ := f(users)
+	expected := []predicate.ContextValue{
+		{Name: "$[0].Age", Value: 32},
+		{Name: "$[1].Age", Value: 28},
+	}
+	if !reflect.DeepEqual(ctx, expected) {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestIsStrictlyIncreasing(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsStrictlyIncreasing()), expectation{value: []int{1, 2, 3}, pass: true})
+	verifyPredicate(t, pr(impl.IsStrictlyIncreasing()), expectation{value: []int{1, 2, 2, 3}, pass: false})
+}
+
+func TestIsDescending(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsDescending()), expectation{value: []int{3, 2, 2, 1}, pass: true})
+	verifyPredicate(t, pr(impl.IsDescending()), expectation{value: []int{3, 1, 2}, pass: false})
+}
+
+func TestHasNoDuplicates(t *testing.T) {
+	verifyPredicate(t, pr(impl.HasNoDuplicates()), expectation{value: []int{1, 2, 3}, pass: true})
+	verifyPredicate(t, pr(impl.HasNoDuplicates()), expectation{value: []int{1, 2, 1}, pass: false})
+	verifyPredicate(t, pr(impl.HasNoDuplicates()), expectation{value: [][]int{{1}, {1}}, pass: false})
+	verifyPredicate(t, pr(impl.HasNoDuplicates()), expectation{
+		value: []*struct{ A int }{{1}, {1}}, pass: false,
+	})
+	verifyPredicate(t, pr(impl.HasNoDuplicatesBy("Manager")), expectation{
+		value: []struct{ Manager *string }{{new(string)}, {new(string)}}, pass: false,
+	})
+	verifyPredicate(t, pr(impl.HasNoDuplicatesBy("Name")), expectation{value: users, pass: true})
+	verifyPredicate(t, pr(impl.HasNoDuplicatesBy("Dept")), expectation{value: users, pass: false})
+
+	_, f := impl.HasNoDuplicatesBy("Dept")
+	_, ctx, _ := f(users)
+	expected := []predicate.ContextValue{
+		{Name: "duplicates", Value: "\"eng\" at indexes 0, 2\n\"ops\" at indexes 1, 3", Pre: true},
+	}
+	if !reflect.DeepEqual(ctx, expected) {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
 	"github.com/maargenton/go-testpredicate/pkg/utils/value"
@@ -85,7 +86,28 @@ func IsEqualTo(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("{} == %v", prettyprint.FormatValue(rhs))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
 		eq, err := value.CompareUnordered(v, rhs)
-		return eq, nil, err
+		if !eq && err == nil {
+			ctx = diffContext(v, rhs, diff.Compare)
+		}
+		return eq, ctx, err
+	}
+	return
+}
+
+// EqWith tests if a value is equal to the specified value, according to
+// `value.DeepEqual()` with the given options. Unlike `Eq()`, the comparison
+// honors comparers registered with `value.RegisterComparer()` and `Equal(T)
+// bool` methods at every level of the values.
+func EqWith(rhs interface{}, opts ...value.EqualOption) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} == %v", prettyprint.FormatValue(rhs))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		eq, err := value.DeepEqual(v, rhs, opts...)
+		if !eq && err == nil {
+			ctx = diffContext(v, rhs, func(v, rhs interface{}) []diff.Difference {
+				return diff.CompareWith(v, rhs, opts...)
+			})
+		}
+		return eq, ctx, err
 	}
 	return
 }
@@ -117,3 +139,67 @@ func Ne(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 
 // Aliases
 // ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for equality predicates
+
+// diffContext returns a `diff` context value listing the differences between a
+// value and the expected value, either as a unified diff for multi-line text
+// or as a structural diff computed by `compare`. Scalar values are skipped
+// since the diff would not add anything to the value itself.
+func diffContext(v, rhs interface{}, compare func(v, rhs interface{}) []diff.Difference) []predicate.ContextValue {
+	if s1, ok := textValue(v); ok {
+		if s2, ok := textValue(rhs); ok {
+			return textDiffContext(s1, s2)
+		}
+	}
+	if !isStructured(v) || !isStructured(rhs) {
+		return nil
+	}
+	diffs := compare(v, rhs)
+	if len(diffs) == 0 {
+		return nil
+	}
+	return []predicate.ContextValue{
+		{Name: "diff", Value: diff.Format(diffs), Pre: true},
+	}
+}
+
+func isStructured(v interface{}) bool {
+	var vv = reflect.ValueOf(v)
+	for vv.Kind() == reflect.Ptr && !vv.IsNil() {
+		vv = vv.Elem()
+	}
+	switch vv.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Array:
+		return true
+	case reflect.Slice:
+		return vv.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// textDiffContext returns a `diff` context value containing a unified diff
+// between two strings, if either of them spans multiple lines.
+func textDiffContext(s, expected string) []predicate.ContextValue {
+	if !diff.IsMultiline(s) && !diff.IsMultiline(expected) {
+		return nil
+	}
+	return []predicate.ContextValue{
+		{Name: "diff", Value: diff.Unified(s, expected), Pre: true},
+	}
+}
+
+// textValue returns the content of a string or a byte slice as a string.
+func textValue(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	}
+	return "", false
+}
+
+// Helper functions for equality predicates
+// ---------------------------------------------------------------------------
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 )
 
 func TestIsTrue(t *testing.T) {
@@ -59,6 +60,37 @@ func TestIsEqualTo(t *testing.T) {
 	})
 }
 
+func TestEqWith(t *testing.T) {
+	verifyPredicate(t, pr(impl.EqWith([]int{})), expectation{value: []int(nil), pass: false})
+	verifyPredicate(t, pr(impl.EqWith([]int{}, value.EquateEmpty())), expectation{
+		value: []int(nil),
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.EqWith(1.0, value.EquateApprox(0.01))), expectation{
+		value: 1.001,
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.EqWith("123")), expectation{
+		value:    123,
+		pass:     false,
+		errorMsg: "values of type 'int' and 'string' are never equal",
+	})
+}
+
+func TestEqWithReportsDiff(t *testing.T) {
+	type Item struct {
+		Name   string
+		Weight float64
+		tags   []string
+	}
+	_, f := impl.EqWith([]Item{{"a", 1, nil}, {"b", 2, nil}},
+		value.EquateApprox(0.01), value.IgnoreUnexported())
+	_, ctx, _ := f([]Item{{"a", 1.001, []string{"x"}}, {"b", 3, nil}})
+	if len(ctx) != 1 || ctx[0].Name != "diff" || ctx[0].Value != "~ .[1].Weight: 2 → 3" {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
 func TestIsNotEqualTo(t *testing.T) {
 	verifyPredicate(t, pr(impl.IsNotEqualTo(123)), expectation{value: 124, pass: true})
 	verifyPredicate(t, pr(impl.IsNotEqualTo(123)), expectation{value: 123, pass: false})
@@ -68,3 +100,37 @@ func TestIsNotEqualTo(t *testing.T) {
 		errorMsg: "values of type 'int' and 'string' are never equal",
 	})
 }
+
+func TestIsEqualToReportsDiff(t *testing.T) {
+	type Item struct {
+		Name  string
+		Count int
+	}
+	_, f := impl.IsEqualTo([]Item{{"a", 1}, {"b", 2}})
+	_, ctx, _ := f([]Item{{"a", 1}, {"b", 3}})
+	if len(ctx) != 1 || ctx[0].Name != "diff" || ctx[0].Value != "~ .[1].Count: 2 → 3" {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+
+	_, f = impl.IsEqualTo(123)
+	_, ctx, _ = f(124)
+	if len(ctx) != 0 {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestIsEqualToReportsTextDiff(t *testing.T) {
+	_, f := impl.IsEqualTo("aaa\nbbb\nccc\n")
+	_, ctx, _ := f([]byte("aaa\nbxb\nccc\n"))
+	expected := "--- expected\n+++ value\n@@ -1,3 +1,3 @@\n" +
+		" aaa\n-bbb\n? ^\n+bxb\n? ^\n ccc"
+	if len(ctx) != 1 || ctx[0].Name != "diff" || ctx[0].Value != expected {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+
+	_, f = impl.IsEqualTo("aaa")
+	_, ctx, _ = f("aab")
+	if len(ctx) != 0 {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
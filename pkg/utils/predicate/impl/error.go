@@ -66,6 +66,9 @@ func IsError(expected any) (desc string, f predicate.PredicateFunc) {
 		} else if expectedRegexp, ok := expected.(*regexp.Regexp); ok && errValue != nil {
 			r = expectedRegexp.MatchString(errValue.Error())
 		}
+		if !r && errValue != nil {
+			ctx = append(ctx, errorTreeContext(errValue))
+		}
 		return
 	}
 	return
@@ -109,3 +112,97 @@ func AsError(target interface{}) (desc string, f predicate.TransformFunc) {
 	}
 	return
 }
+
+// IsErrorOfType tests if a value is an error of type `T`, or an error wrapping
+// an error of type `T` anywhere in its unwrap tree, according to
+// `errors.As()`. As a generic function, it is not available through the
+// builder API and must be used with `Is()`:
+//
+//	verify.That(t, err).Is(impl.IsErrorOfType[*MyError]())
+func IsErrorOfType[T error]() (desc string, f predicate.PredicateFunc) {
+	var t = reflect.TypeOf((*T)(nil)).Elem()
+	desc = fmt.Sprintf("{} is error of type '%v'", t)
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		var errValue, isError = v.(error)
+		if !isError && v != nil {
+			err = fmt.Errorf("value of type '%T' is not an error", v)
+			return
+		}
+		var target T
+		r = errValue != nil && errors.As(errValue, &target)
+		if !r && errValue != nil {
+			ctx = []predicate.ContextValue{errorTreeContext(errValue)}
+		}
+		return
+	}
+	return
+}
+
+// ErrorChain is a transformation predicate that flattens the unwrap tree of
+// an error into a slice of errors, starting with the error itself, followed
+// depth-first by the errors it wraps through either `Unwrap() error` or
+// `Unwrap() []error`, as produced by `errors.Join()`. A nil error yields an
+// empty slice.
+func ErrorChain() (desc string, f predicate.TransformFunc) {
+	desc = "ErrorChain({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		var errValue, isError = v.(error)
+		if !isError && v != nil {
+			err = fmt.Errorf("value of type '%T' is not an error", v)
+			return
+		}
+		var chain = []error{}
+		walkErrorTree(errValue, 0, func(e error, depth int) {
+			chain = append(chain, e)
+		})
+		r = chain
+		if errValue != nil {
+			ctx = []predicate.ContextValue{errorTreeContext(errValue)}
+		}
+		return
+	}
+	return
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for error trees
+
+// maxErrorTreeDepth bounds the depth of the error trees walked by
+// `walkErrorTree()`, as an `Unwrap()` method returning its own receiver or
+// otherwise forming a cycle would never terminate.
+const maxErrorTreeDepth = 100
+
+// walkErrorTree calls `fn` on an error and, depth-first, on all the errors it
+// wraps, up to `maxErrorTreeDepth` levels deep.
+func walkErrorTree(err error, depth int, fn func(err error, depth int)) {
+	if err == nil || depth > maxErrorTreeDepth {
+		return
+	}
+	fn(err, depth)
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		walkErrorTree(e.Unwrap(), depth+1, fn)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			walkErrorTree(child, depth+1, fn)
+		}
+	}
+}
+
+// errorTreeContext returns a context value describing the unwrap tree of an
+// error, with the concrete type and message of each error, one per line.
+func errorTreeContext(err error) predicate.ContextValue {
+	var lines []string
+	walkErrorTree(err, 0, func(e error, depth int) {
+		lines = append(lines, fmt.Sprintf("%v%T: %q",
+			strings.Repeat("  ", depth), e, e.Error()))
+	})
+	return predicate.ContextValue{
+		Name:  "error tree",
+		Value: strings.Join(lines, "\n"),
+		Pre:   true,
+	}
+}
+
+// Helper functions for error trees
+// ---------------------------------------------------------------------------
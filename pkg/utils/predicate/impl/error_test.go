@@ -1,8 +1,10 @@
 package impl_test
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
@@ -88,3 +90,89 @@ func TestAsError(t *testing.T) {
 		errorMsg: "value of type 'int' is not an error",
 	})
 }
+
+func TestIsErrorFailureContext(t *testing.T) {
+	var sentinel = fmt.Errorf("sentinel")
+	var err = fmt.Errorf("wrapper: %w", errors.Join(sentinel, &MyError{Code: 123}))
+
+	_, f := impl.IsError("other")
+	_, ctx, _ := f(err)
+	var expected = strings.Join([]string{
+		`*fmt.wrapError: "wrapper: sentinel\nMyError(123)"`,
+		`  *errors.joinError: "sentinel\nMyError(123)"`,
+		`    *errors.errorString: "sentinel"`,
+		`    *impl_test.MyError: "MyError(123)"`,
+	}, "\n")
+	if len(ctx) != 2 || ctx[1].Name != "error tree" || ctx[1].Value != expected || !ctx[1].Pre {
+		t.Errorf("\nUnexpected ctx: %+v", ctx)
+	}
+
+	_, ctx, _ = f(nil)
+	if len(ctx) != 0 {
+		t.Errorf("\nUnexpected ctx: %+v", ctx)
+	}
+
+	_, f = impl.IsError(sentinel)
+	_, ctx, _ = f(err)
+	if len(ctx) != 1 || ctx[0].Name != "message" {
+		t.Errorf("\nUnexpected ctx: %+v", ctx)
+	}
+}
+
+func TestIsErrorOfType(t *testing.T) {
+	var myErr = &MyError{Code: 123}
+	var joined = errors.Join(fmt.Errorf("other"), fmt.Errorf("wrapper: %w", myErr))
+
+	verifyPredicate(t, pr(impl.IsErrorOfType[*MyError]()), expectation{value: myErr, pass: true})
+	verifyPredicate(t, pr(impl.IsErrorOfType[*MyError]()), expectation{value: joined, pass: true})
+	verifyPredicate(t, pr(impl.IsErrorOfType[*MyError]()), expectation{value: fmt.Errorf("other"), pass: false})
+	verifyPredicate(t, pr(impl.IsErrorOfType[*MyError]()), expectation{value: nil, pass: false})
+	verifyPredicate(t, pr(impl.IsErrorOfType[*MyError]()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not an error",
+	})
+
+	desc, _ := impl.IsErrorOfType[*MyError]()
+	if desc != "{} is error of type '*impl_test.MyError'" {
+		t.Errorf("\nUnexpected description: %v", desc)
+	}
+}
+
+func TestErrorChain(t *testing.T) {
+	var sentinel = fmt.Errorf("sentinel")
+	var myErr = &MyError{Code: 123}
+	var inner = fmt.Errorf("inner: %w", sentinel)
+	var joined = errors.Join(inner, myErr)
+	var err = fmt.Errorf("outer: %w", joined)
+
+	verifyTransform(t, tr(impl.ErrorChain()), expectation{
+		value:  err,
+		result: []error{err, joined, inner, sentinel, myErr},
+	})
+	verifyTransform(t, tr(impl.ErrorChain()), expectation{
+		value:  sentinel,
+		result: []error{sentinel},
+	})
+	verifyTransform(t, tr(impl.ErrorChain()), expectation{
+		value:  nil,
+		result: []error{},
+	})
+	verifyTransform(t, tr(impl.ErrorChain()), expectation{
+		value:    "error",
+		errorMsg: "value of type 'string' is not an error",
+	})
+}
+
+type selfWrappingError struct{}
+
+func (err *selfWrappingError) Error() string { return "self-wrapping" }
+func (err *selfWrappingError) Unwrap() error { return err }
+
+func TestErrorChainWithCycle(t *testing.T) {
+	var err = &selfWrappingError{}
+	_, f := impl.ErrorChain()
+	r, ctx, _ := f(err)
+	if chain, ok := r.([]error); !ok || len(chain) != 101 || len(ctx) != 1 {
+		t.Errorf("\nunexpected error chain for cyclic error: %v", r)
+	}
+}
@@ -0,0 +1,321 @@
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+)
+
+// ---------------------------------------------------------------------------
+// JSON predicates and transformations
+
+// IsJSONEqual tests if a value is a JSON document semantically equivalent to
+// the expected one, ignoring key order in objects and comparing numbers
+// numerically. Both the value and the expected document can be provided as a
+// string, a byte slice or an io.Reader containing JSON, or as any other value
+// that gets marshaled to JSON.
+func IsJSONEqual(expected interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("json({}) ≡ %v", prettyprint.FormatValue(expected))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		lhs, err := decodeJSON(v)
+		if err != nil {
+			return false, nil, err
+		}
+		rhs, err := decodeJSON(expected)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid expected value: %w", err)
+		}
+
+		if reflect.DeepEqual(lhs, rhs) {
+			return true, nil, nil
+		}
+		if diffs := diff.Compare(lhs, rhs); len(diffs) > 0 {
+			ctx = []predicate.ContextValue{
+				{Name: "diff", Value: diff.Format(diffs), Pre: true},
+			}
+		}
+		return false, ctx, nil
+	}
+	return
+}
+
+// ParseJSON is a transformation that decodes a JSON document, provided as a
+// string, a byte slice or an io.Reader, into a tree of `map[string]any`,
+// `[]any`, `string`, `float64`, `bool` and `nil` values for further
+// evaluation. Integers too large to be represented exactly as float64 are kept
+// as `json.Number`.
+func ParseJSON() (desc string, f predicate.TransformFunc) {
+	desc = "json({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = parseJSON(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: "json", Value: r},
+		}, nil
+	}
+	return
+}
+
+// JSONPath is a transformation that decodes a JSON document, if not already
+// decoded, and extracts the value identified by `path` for further
+// evaluation. The supported path syntax includes the root `$`, child names as
+// `.name` or `["name"]`, array indexes as `[i]`, where negative indexes count
+// from the end, and `[*]` wildcards that collect the matching values of all
+// children into an array.
+func JSONPath(path string) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("json({})%v", strings.TrimPrefix(path, "$"))
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		steps, err := parseJSONPath(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !isDecodedJSON(v) {
+			if v, err = parseJSON(v); err != nil {
+				return nil, nil, err
+			}
+		}
+		r, err = evalJSONPath(v, steps, "$")
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, []predicate.ContextValue{
+			{Name: path, Value: r},
+		}, nil
+	}
+	return
+}
+
+// JSON predicates and transformations
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for JSON decoding
+
+// parseJSON decodes a JSON document provided as a string, a byte slice or an
+// io.Reader.
+func parseJSON(v interface{}) (r interface{}, err error) {
+	var reader io.Reader
+	switch v := v.(type) {
+	case string:
+		reader = strings.NewReader(v)
+	case []byte:
+		reader = bytes.NewReader(v)
+	case json.RawMessage:
+		reader = bytes.NewReader(v)
+	case io.Reader:
+		reader = v
+	default:
+		return nil, fmt.Errorf("value of type '%T' is not a JSON document", v)
+	}
+
+	var dec = json.NewDecoder(reader)
+	dec.UseNumber()
+	if err = dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid JSON document: unexpected data after top-level value")
+	}
+	return normalizeJSON(r), nil
+}
+
+// decodeJSON returns the normalized decoded JSON tree of a value, either by
+// parsing it if it is a JSON document, or by marshaling it to JSON first.
+func decodeJSON(v interface{}) (interface{}, error) {
+	switch v.(type) {
+	case string, []byte, json.RawMessage, io.Reader:
+		return parseJSON(v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("value of type '%T' cannot be marshaled to JSON: %w", v, err)
+	}
+	return parseJSON(data)
+}
+
+// normalizeJSON converts all the `json.Number` values of a decoded tree into
+// float64, unless they are integers that cannot be represented exactly, which
+// are kept as `json.Number` to be compared exactly.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+	case json.Number:
+		if !strings.ContainsAny(string(v), ".eE") {
+			if i, err := v.Int64(); err == nil && i <= 1<<53 && i >= -(1<<53) {
+				return float64(i)
+			}
+			return v
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+func isDecodedJSON(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, float64, bool, nil, json.Number:
+		return true
+	}
+	return false
+}
+
+// Helper functions for JSON decoding
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for JSON path evaluation
+
+type jsonPathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (s jsonPathStep) String() string {
+	switch {
+	case s.wildcard:
+		return "[*]"
+	case s.isIndex:
+		return fmt.Sprintf("[%v]", s.index)
+	default:
+		return "." + s.key
+	}
+}
+
+func parseJSONPath(path string) (steps []jsonPathStep, err error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSON path '%v': must start with '$'", path)
+	}
+	var s = path[1:]
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".*") || strings.HasPrefix(s, "[*]"):
+			steps = append(steps, jsonPathStep{wildcard: true})
+			s = strings.TrimPrefix(strings.TrimPrefix(s, ".*"), "[*]")
+
+		case s[0] == '.':
+			var end = strings.IndexAny(s[1:], ".[")
+			if end < 0 {
+				end = len(s) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON path '%v': empty name", path)
+			}
+			steps = append(steps, jsonPathStep{key: s[1 : end+1]})
+			s = s[end+1:]
+
+		case s[0] == '[':
+			var end = strings.Index(s, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path '%v': missing ']'", path)
+			}
+			var content = s[1:end]
+			if len(content) >= 2 && (content[0] == '"' || content[0] == '\'') &&
+				content[len(content)-1] == content[0] {
+
+				steps = append(steps, jsonPathStep{key: content[1 : len(content)-1]})
+			} else if i, err := strconv.Atoi(content); err == nil {
+				steps = append(steps, jsonPathStep{index: i, isIndex: true})
+			} else {
+				return nil, fmt.Errorf("invalid JSON path '%v': invalid index '%v'", path, content)
+			}
+			s = s[end+1:]
+
+		default:
+			return nil, fmt.Errorf("invalid JSON path '%v': unexpected '%v'", path, s)
+		}
+	}
+	return
+}
+
+func evalJSONPath(v interface{}, steps []jsonPathStep, prefix string) (interface{}, error) {
+	if len(steps) == 0 {
+		return v, nil
+	}
+	var step, rest = steps[0], steps[1:]
+	var path = prefix + step.String()
+
+	switch {
+	case step.wildcard:
+		var r = []interface{}{}
+		switch v := v.(type) {
+		case []interface{}:
+			for i, e := range v {
+				ev, err := evalJSONPath(e, rest, fmt.Sprintf("%v[%v]", prefix, i))
+				if err != nil {
+					return nil, err
+				}
+				r = append(r, ev)
+			}
+		case map[string]interface{}:
+			for _, k := range sortedJSONKeys(v) {
+				ev, err := evalJSONPath(v[k], rest, prefix+"."+k)
+				if err != nil {
+					return nil, err
+				}
+				r = append(r, ev)
+			}
+		default:
+			return nil, fmt.Errorf("no children at '%v' in value of type '%T'", prefix, v)
+		}
+		return r, nil
+
+	case step.isIndex:
+		a, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("no index at '%v' in value of type '%T'", prefix, v)
+		}
+		var i = step.index
+		if i < 0 {
+			i += len(a)
+		}
+		if i < 0 || i >= len(a) {
+			return nil, fmt.Errorf("index out of range at '%v', length is %v", path, len(a))
+		}
+		return evalJSONPath(a[i], rest, path)
+
+	default:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("no key at '%v' in value of type '%T'", prefix, v)
+		}
+		e, ok := m[step.key]
+		if !ok {
+			return nil, fmt.Errorf("no key '%v' at '%v'", step.key, prefix)
+		}
+		return evalJSONPath(e, rest, path)
+	}
+}
+
+func sortedJSONKeys(m map[string]interface{}) []string {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Helper functions for JSON path evaluation
+// ---------------------------------------------------------------------------
@@ -0,0 +1,131 @@
+package impl_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+)
+
+func TestIsJSONEqual(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"a": 1, "b": [true, null]}`)), expectation{
+		value: `{"b":[true,null],"a":1.0}`,
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"a": 1e2}`)), expectation{
+		value: []byte(`{"a": 100}`),
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"a": 1}`)), expectation{
+		value: strings.NewReader(`{"a": 2}`),
+		pass:  false,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"id": 9007199254740993}`)), expectation{
+		value: `{"id": 9007199254740992}`,
+		pass:  false,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"id": 123456789012345678901}`)), expectation{
+		value: `{"id": 123456789012345678902}`,
+		pass:  false,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"id": -123456789012345678901}`)), expectation{
+		value: `{"id": -123456789012345678901}`,
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(map[string]interface{}{"a": 1})), expectation{
+		value: struct {
+			A int `json:"a"`
+		}{1},
+		pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"a": 1}`)), expectation{
+		value:    `{"a": `,
+		errorMsg: "invalid JSON document: unexpected EOF",
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{"a": 1} {}`)), expectation{
+		value:    `{"a": 1}`,
+		errorMsg: "invalid expected value: invalid JSON document: unexpected data after top-level value",
+	})
+	verifyPredicate(t, pr(impl.IsJSONEqual(`{}`)), expectation{
+		value:    func() {},
+		errorMsg: "value of type 'func()' cannot be marshaled to JSON",
+	})
+}
+
+func TestIsJSONEqualReportsDiff(t *testing.T) {
+	_, f := impl.IsJSONEqual(`{"items": [{"id": 1}, {"id": 2}], "name": "x"}`)
+	_, ctx, _ := f(`{"items": [{"id": 1}, {"id": 3}], "extra": true, "name": "x"}`)
+	expected := "+ .extra: true\n~ .items[1].id: 2 → 3"
+	if len(ctx) != 1 || ctx[0].Name != "diff" || ctx[0].Value != expected {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	verifyTransform(t, tr(impl.ParseJSON()), expectation{
+		value: `{"a": [1, "b", 1.5, 9007199254740993]}`,
+		result: map[string]interface{}{
+			"a": []interface{}{1.0, "b", 1.5, json.Number("9007199254740993")},
+		},
+	})
+	verifyTransform(t, tr(impl.ParseJSON()), expectation{
+		value:  strings.NewReader(`true`),
+		result: true,
+	})
+	verifyTransform(t, tr(impl.ParseJSON()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a JSON document",
+	})
+}
+
+func TestJSONPath(t *testing.T) {
+	const doc = `{
+		"items": [{"id": 1, "tags": ["a"]}, {"id": 2, "tags": []}],
+		"meta": {"total.count": 2}
+	}`
+	verifyTransform(t, tr(impl.JSONPath("$.items[0].id")), expectation{
+		value:  doc,
+		result: 1.0,
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.items[-1].id")), expectation{
+		value:  []byte(doc),
+		result: 2.0,
+	})
+	verifyTransform(t, tr(impl.JSONPath(`$.meta["total.count"]`)), expectation{
+		value:  doc,
+		result: 2.0,
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.items[*].id")), expectation{
+		value:  doc,
+		result: []interface{}{1.0, 2.0},
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.meta.*")), expectation{
+		value:  doc,
+		result: []interface{}{2.0},
+	})
+	verifyTransform(t, tr(impl.JSONPath("$")), expectation{
+		value:  map[string]interface{}{"a": 1.0},
+		result: map[string]interface{}{"a": 1.0},
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.items[2]")), expectation{
+		value:    doc,
+		errorMsg: "index out of range at '$.items[2]', length is 2",
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.items[0].name")), expectation{
+		value:    doc,
+		errorMsg: "no key 'name' at '$.items[0]'",
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.items.id")), expectation{
+		value:    doc,
+		errorMsg: "no key at '$.items' in value of type '[]interface {}'",
+	})
+	verifyTransform(t, tr(impl.JSONPath("items")), expectation{
+		value:    doc,
+		errorMsg: "invalid JSON path 'items': must start with '$'",
+	})
+	verifyTransform(t, tr(impl.JSONPath("$.items[x]")), expectation{
+		value:    doc,
+		errorMsg: "invalid JSON path '$.items[x]': invalid index 'x'",
+	})
+}
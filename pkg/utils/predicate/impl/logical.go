@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+// ---------------------------------------------------------------------------
+// Logical combinators of sub-expressions
+
+// Not tests that a value does not match the given sub-expression predicate.
+func Not(p *predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("¬(%v)", p.FormatDescription("{}"))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		success, subctx := p.Evaluate(v)
+		if subctx, err = evaluationError(subctx); err != nil {
+			return false, branchContext("failed", 0, p, subctx), err
+		}
+		if success {
+			ctx = branchContext("passed", 0, p, subctx)
+		}
+		return !success, ctx, nil
+	}
+	return
+}
+
+// AllOf tests that a value matches all of the given sub-expression predicates.
+// All predicates are evaluated and each failing one is reported.
+func AllOf(ps ...*predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = joinDescriptions(ps, " ∧ ")
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		r = true
+		for i, p := range ps {
+			if success, subctx := p.Evaluate(v); !success {
+				r = false
+				ctx = append(ctx, branchContext("failed", i, p, subctx)...)
+			}
+		}
+		return
+	}
+	return
+}
+
+// AnyOf tests that a value matches at least one of the given sub-expression
+// predicates. Upon failure, each failing predicate is reported.
+func AnyOf(ps ...*predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = joinDescriptions(ps, " ∨ ")
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		for i, p := range ps {
+			success, subctx := p.Evaluate(v)
+			if success {
+				return true, nil, nil
+			}
+			ctx = append(ctx, branchContext("failed", i, p, subctx)...)
+		}
+		return false, ctx, nil
+	}
+	return
+}
+
+// NoneOf tests that a value matches none of the given sub-expression
+// predicates. All predicates are evaluated and each passing one is reported.
+func NoneOf(ps ...*predicate.Predicate) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("¬(%v)", joinDescriptions(ps, " ∨ "))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		r = true
+		for i, p := range ps {
+			success, subctx := p.Evaluate(v)
+			if subctx, err = evaluationError(subctx); err != nil {
+				return false, branchContext("failed", i, p, subctx), err
+			}
+			if success {
+				r = false
+				ctx = append(ctx, branchContext("passed", i, p, subctx)...)
+			}
+		}
+		return
+	}
+	return
+}
+
+// IsOneOf tests if a value is equal to any of the specified values.
+func IsOneOf(values ...interface{}) (desc string, f predicate.PredicateFunc) {
+	var formatted []string
+	for _, v := range values {
+		formatted = append(formatted, prettyprint.FormatValue(v))
+	}
+	if len(formatted) == 0 {
+		desc = "{} ∈ ∅"
+	} else {
+		desc = fmt.Sprintf("{} ∈ {%v}", strings.Join(formatted, ", "))
+	}
+
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		var comparable = false
+		var firstErr error
+		for _, rhs := range values {
+			eq, err := value.CompareUnordered(v, rhs)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			comparable = true
+			if eq {
+				return true, nil, nil
+			}
+		}
+		if !comparable && firstErr != nil {
+			return false, nil, firstErr
+		}
+		return false, nil, nil
+	}
+	return
+}
+
+// Logical combinators of sub-expressions
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for logical combinators
+
+func joinDescriptions(ps []*predicate.Predicate, sep string) string {
+	var descs []string
+	for _, p := range ps {
+		descs = append(descs, fmt.Sprintf("(%v)", p.FormatDescription("{}")))
+	}
+	return strings.Join(descs, sep)
+}
+
+// evaluationError extracts the error reported in the context of a
+// sub-expression evaluation, if any, and returns the remaining context.
+// `Evaluate()` reports errors as failures, which must not be mistaken for
+// regular failures when negating or filtering on the result.
+func evaluationError(ctx []predicate.ContextValue) ([]predicate.ContextValue, error) {
+	for i, v := range ctx {
+		if err, ok := v.Value.(error); ok && v.Name == "error" {
+			var rest = append([]predicate.ContextValue{}, ctx[:i]...)
+			return append(rest, ctx[i+1:]...), err
+		}
+	}
+	return ctx, nil
+}
+
+// branchContext returns the context of a sub-expression evaluation, introduced
+// by a `label` value containing the sub-expression description. All context
+// value names are suffixed with the branch number to tell them apart.
+func branchContext(
+	label string, index int, p *predicate.Predicate,
+	ctx []predicate.ContextValue) (result []predicate.ContextValue) {
+
+	var suffix = fmt.Sprintf(" #%v", index+1)
+	result = append(result, predicate.ContextValue{
+		Name:  label + suffix,
+		Value: p.FormatDescription("value"),
+		Pre:   true,
+	})
+	for _, v := range ctx {
+		if v.Name == "expected" || v.Name == "value" {
+			continue
+		}
+		v.Name += suffix
+		result = append(result, v)
+	}
+	return result
+}
+
+// Helper functions for logical combinators
+// ---------------------------------------------------------------------------
@@ -0,0 +1,117 @@
+package impl_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
+)
+
+func subPredicate(desc string, f predicate.PredicateFunc) *predicate.Predicate {
+	var p = &predicate.Predicate{}
+	p.RegisterPredicate(desc, f)
+	return p
+}
+
+func TestNot(t *testing.T) {
+	var p = subPredicate(impl.Lt(3))
+
+	verifyPredicate(t, pr(impl.Not(p)), expectation{value: 3, pass: true})
+	verifyPredicate(t, pr(impl.Not(p)), expectation{value: 2, pass: false})
+	verifyPredicate(t, pr(impl.Not(subPredicate(impl.IsEmpty()))), expectation{
+		value:    42,
+		errorMsg: "value of type 'int' cannot be tested for emptiness",
+	})
+}
+
+func TestNotContextOnError(t *testing.T) {
+	_, f := impl.Not(subPredicate(impl.IsEmpty()))
+	_, ctx, err := f(42)
+	if err == nil || len(ctx) != 1 || ctx[0].Name != "failed #1" {
+		t.Errorf("\nUnexpected result: %+v, %v", ctx, err)
+	}
+}
+
+func TestAllOf(t *testing.T) {
+	var p = pr(impl.AllOf(subPredicate(impl.Gt(1)), subPredicate(impl.Lt(3))))
+
+	verifyPredicate(t, p, expectation{value: 2, pass: true})
+	verifyPredicate(t, p, expectation{value: 1, pass: false})
+	verifyPredicate(t, p, expectation{value: 3, pass: false})
+}
+
+func TestAnyOf(t *testing.T) {
+	var p = pr(impl.AnyOf(subPredicate(impl.Lt(1)), subPredicate(impl.Gt(3))))
+
+	verifyPredicate(t, p, expectation{value: 0, pass: true})
+	verifyPredicate(t, p, expectation{value: 4, pass: true})
+	verifyPredicate(t, p, expectation{value: 2, pass: false})
+}
+
+func TestNoneOf(t *testing.T) {
+	var p = pr(impl.NoneOf(subPredicate(impl.Lt(1)), subPredicate(impl.Gt(3))))
+
+	verifyPredicate(t, p, expectation{value: 2, pass: true})
+	verifyPredicate(t, p, expectation{value: 0, pass: false})
+	verifyPredicate(t, p, expectation{value: 4, pass: false})
+	verifyPredicate(t, pr(impl.NoneOf(subPredicate(impl.IsEmpty()))), expectation{
+		value:    42,
+		errorMsg: "value of type 'int' cannot be tested for emptiness",
+	})
+	verifyPredicate(t, pr(impl.NoneOf(subPredicate(impl.Lt(1)), subPredicate(impl.IsEmpty()))), expectation{
+		value:    2,
+		errorMsg: "value of type 'int' cannot be tested for emptiness",
+	})
+}
+
+func TestLogicalDescriptions(t *testing.T) {
+	var p = subPredicate(impl.Lt(3))
+	var q = subPredicate(impl.Gt(1))
+
+	if desc, _ := impl.Not(p); desc != "¬({} < 3)" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	if desc, _ := impl.AllOf(p, q); desc != "({} < 3) ∧ ({} > 1)" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	if desc, _ := impl.AnyOf(p, q); desc != "({} < 3) ∨ ({} > 1)" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	if desc, _ := impl.NoneOf(p, q); desc != "¬(({} < 3) ∨ ({} > 1))" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	if desc, _ := impl.IsOneOf(1, "a"); desc != `{} ∈ {1, "a"}` {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+}
+
+func TestAllOfReportsFailedBranches(t *testing.T) {
+	var p = subPredicate(impl.Gt(5))
+	p.RegisterTransformation(impl.Length())
+	var q = subPredicate(impl.Lt(3))
+	q.RegisterTransformation(impl.Length())
+
+	_, f := impl.AllOf(p, q)
+	_, ctx, _ := f("abcd")
+	var names []string
+	for _, c := range ctx {
+		names = append(names, c.Name)
+	}
+	if len(ctx) != 4 || ctx[0].Name != "failed #1" ||
+		ctx[0].Value != "length(value) > 5" ||
+		ctx[1].Name != "length #1" || ctx[2].Name != "failed #2" {
+
+		t.Errorf("\nunexpected context: %v", names)
+	}
+}
+
+func TestIsOneOf(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsOneOf(1, 2, 3)), expectation{value: 2, pass: true})
+	verifyPredicate(t, pr(impl.IsOneOf(1, 2, 3)), expectation{value: 4, pass: false})
+	verifyPredicate(t, pr(impl.IsOneOf(1, "2")), expectation{value: "2", pass: true})
+	verifyPredicate(t, pr(impl.IsOneOf()), expectation{value: 4, pass: false})
+	verifyPredicate(t, pr(impl.IsOneOf("1", "2")), expectation{
+		value:    1,
+		errorMsg: "values of type 'int' and 'string' are never equal",
+	})
+}
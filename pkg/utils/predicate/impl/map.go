@@ -3,8 +3,11 @@ package impl
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 )
 
 // MapKeys is a transformation predicate that applies only to map values and
@@ -41,6 +44,165 @@ func MapValues() (desc string, f predicate.TransformFunc) {
 	return
 }
 
+// Lookup is a transformation predicate that applies only to map values and
+// extracts the value associated with the given key for further evaluation.
+// Unlike `Field()`, it fails with an error if the key is missing.
+func Lookup(k interface{}) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("{}[%v]", prettyprint.FormatValue(k))
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		vv, err := mapValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		e, ok := mapLookup(vv, k)
+		if !ok {
+			err = fmt.Errorf("key %v not found", prettyprint.FormatValue(k))
+			if nearest := nearestKeys(vv, k); nearest != "" {
+				err = fmt.Errorf("%w, nearest keys: %v", err, nearest)
+			}
+			return nil, nil, err
+		}
+		r = e.Interface()
+		return r, []predicate.ContextValue{
+			{Name: fmt.Sprintf("$[%v]", prettyprint.FormatValue(k)), Value: r},
+		}, nil
+	}
+	return
+}
+
+// HasKey tests if a map contains the given key.
+func HasKey(k interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} has key %v", prettyprint.FormatValue(k))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		vv, err := mapValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+		if _, ok := mapLookup(vv, k); ok {
+			return true, nil, nil
+		}
+		return false, nearestKeysContext(vv, k), nil
+	}
+	return
+}
+
+// HasKeys tests if a map contains all of the given keys.
+func HasKeys(ks ...interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} has keys %v", formatValueList(ks))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		vv, err := mapValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+		var missing []interface{}
+		for _, k := range ks {
+			if _, ok := mapLookup(vv, k); !ok {
+				missing = append(missing, k)
+			}
+		}
+		if len(missing) == 0 {
+			return true, nil, nil
+		}
+		ctx = []predicate.ContextValue{
+			{Name: "missing keys", Value: formatValueList(missing), Pre: true},
+		}
+		ctx = append(ctx, nearestKeysContext(vv, missing...)...)
+		return false, ctx, nil
+	}
+	return
+}
+
+// HasEntry tests if a map contains the given key, associated with a value
+// equal to the given value.
+func HasEntry(k, e interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{}[%v] == %v",
+		prettyprint.FormatValue(k), prettyprint.FormatValue(e))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		vv, err := mapValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+		actual, ok := mapLookup(vv, k)
+		if !ok {
+			ctx = []predicate.ContextValue{
+				{Name: "missing key", Value: prettyprint.FormatValue(k), Pre: true},
+			}
+			return false, append(ctx, nearestKeysContext(vv, k)...), nil
+		}
+		eq, err := value.CompareUnordered(actual.Interface(), e)
+		if err != nil {
+			return false, nil, fmt.Errorf("value at key %v cannot be compared: %w",
+				prettyprint.FormatValue(k), err)
+		}
+		if eq {
+			return true, nil, nil
+		}
+		return false, []predicate.ContextValue{
+			{Name: fmt.Sprintf("$[%v]", prettyprint.FormatValue(k)), Value: actual.Interface()},
+		}, nil
+	}
+	return
+}
+
+// ContainsEntries tests if a map contains all the entries of the given map,
+// with equal values, independently of any other entry.
+func ContainsEntries(entries interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} contains entries %v", prettyprint.FormatValue(entries))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		vv, err := mapValue(v)
+		if err != nil {
+			return false, nil, err
+		}
+		ee := reflect.ValueOf(entries)
+		if ee.Kind() != reflect.Map {
+			return false, nil, fmt.Errorf(
+				"value of type '%T' is not a map of expected entries", entries)
+		}
+
+		expected, err := value.Collect(entries)
+		if err != nil {
+			return false, nil, err
+		}
+
+		var missing []interface{}
+		var mismatched []string
+		for _, entry := range expected.([]value.KeyValue) {
+			actual, ok := mapLookup(vv, entry.Key)
+			if !ok {
+				missing = append(missing, entry.Key)
+				continue
+			}
+			eq, err := value.CompareUnordered(actual.Interface(), entry.Value)
+			if err != nil {
+				return false, nil, fmt.Errorf("value at key %v cannot be compared: %w",
+					prettyprint.FormatValue(entry.Key), err)
+			}
+			if !eq {
+				mismatched = append(mismatched, fmt.Sprintf("%v: %v, expected %v",
+					prettyprint.FormatValue(entry.Key),
+					prettyprint.FormatValue(actual.Interface()),
+					prettyprint.FormatValue(entry.Value)))
+			}
+		}
+		if len(missing) == 0 && len(mismatched) == 0 {
+			return true, nil, nil
+		}
+		if len(missing) > 0 {
+			ctx = append(ctx, predicate.ContextValue{
+				Name: "missing keys", Value: formatValueList(missing), Pre: true,
+			})
+		}
+		if len(mismatched) > 0 {
+			ctx = append(ctx, predicate.ContextValue{
+				Name: "mismatched values", Value: strings.Join(mismatched, "\n"), Pre: true,
+			})
+		}
+		ctx = append(ctx, nearestKeysContext(vv, missing...)...)
+		return false, ctx, nil
+	}
+	return
+}
+
 // ---------------------------------------------------------------------------
 // Helper functions to manipulate maps through the reflect package
 
@@ -79,5 +241,91 @@ func extractMapValues(v interface{}) (r interface{}, err error) {
 	return
 }
 
+// mapValue returns the reflected value of a map, or an error if the value is
+// not a map.
+func mapValue(v interface{}) (reflect.Value, error) {
+	vv := reflect.ValueOf(v)
+	if vv.Kind() != reflect.Map {
+		return reflect.Value{}, fmt.Errorf("value of type '%T' is not a map", v)
+	}
+	return vv, nil
+}
+
+// mapLookup returns the value associated with a key in a map. If the key is
+// not directly assignable to the map key type, it is matched against the
+// existing keys with `value.CompareUnordered()`, allowing for instance an `int`
+// key to be found in a `map[int64]...`.
+func mapLookup(m reflect.Value, k interface{}) (reflect.Value, bool) {
+	kv := reflect.ValueOf(k)
+	keyType := m.Type().Key()
+	if !kv.IsValid() {
+		switch keyType.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Chan:
+			kv = reflect.Zero(keyType)
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	if kv.Type().AssignableTo(keyType) && kv.Type().Comparable() {
+		e := m.MapIndex(kv)
+		return e, e.IsValid()
+	}
+	for it := m.MapRange(); it.Next(); {
+		if eq, err := value.CompareUnordered(it.Key().Interface(), k); eq && err == nil {
+			return it.Value(), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// nearestKeys returns a formatted list of the existing string keys of a map
+// that are the closest to any of the given keys, or an empty string if there
+// are none.
+func nearestKeys(m reflect.Value, ks ...interface{}) string {
+	if m.Type().Key().Kind() != reflect.String {
+		return ""
+	}
+	var candidates []string
+	for it := m.MapRange(); it.Next(); {
+		candidates = append(candidates, it.Key().String())
+	}
+
+	var nearest []interface{}
+	var seen = map[string]bool{}
+	for _, k := range ks {
+		kv := reflect.ValueOf(k)
+		if kv.Kind() != reflect.String {
+			continue
+		}
+		for _, c := range value.Nearest(kv.String(), candidates, 3) {
+			if !seen[c] {
+				seen[c] = true
+				nearest = append(nearest, c)
+			}
+		}
+	}
+	if len(nearest) == 0 {
+		return ""
+	}
+	return formatValueList(nearest)
+}
+
+func nearestKeysContext(m reflect.Value, ks ...interface{}) []predicate.ContextValue {
+	if nearest := nearestKeys(m, ks...); nearest != "" {
+		return []predicate.ContextValue{
+			{Name: "nearest keys", Value: nearest, Pre: true},
+		}
+	}
+	return nil
+}
+
+func formatValueList(values []interface{}) string {
+	var formatted []string
+	for _, v := range values {
+		formatted = append(formatted, prettyprint.FormatValue(v))
+	}
+	return strings.Join(formatted, ", ")
+}
+
 // Helper functions to manipulate maps through the reflect package
 // ---------------------------------------------------------------------------
@@ -33,3 +33,114 @@ func TestMapValues(t *testing.T) {
 		errorMsg: "value of type 'int' does not have values",
 	})
 }
+
+func TestLookup(t *testing.T) {
+	verifyTransform(t, tr(impl.Lookup("aaa")), expectation{
+		value:  map[string]string{"aaa": "bbb"},
+		result: "bbb",
+	})
+	verifyTransform(t, tr(impl.Lookup(1)), expectation{
+		value:  map[int64]string{1: "one"},
+		result: "one",
+	})
+	verifyTransform(t, tr(impl.Lookup("aab")), expectation{
+		value:    map[string]string{"aaa": "bbb", "ccc": "ddd"},
+		errorMsg: `key "aab" not found, nearest keys: "aaa"`,
+	})
+	verifyTransform(t, tr(impl.Lookup(2)), expectation{
+		value:    map[int]string{1: "one"},
+		errorMsg: "key 2 not found",
+	})
+	verifyTransform(t, tr(impl.Lookup("aaa")), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a map",
+	})
+}
+
+func TestHasKey(t *testing.T) {
+	var m = map[string]int{"aaa": 1, "ccc": 2}
+	verifyPredicate(t, pr(impl.HasKey("aaa")), expectation{value: m, pass: true})
+	verifyPredicate(t, pr(impl.HasKey("bbb")), expectation{value: m, pass: false})
+	verifyPredicate(t, pr(impl.HasKey(nil)), expectation{
+		value: map[interface{}]int{nil: 1},
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.HasKey("aaa")), expectation{
+		value:    []string{"aaa"},
+		errorMsg: "value of type '[]string' is not a map",
+	})
+}
+
+func TestHasKeys(t *testing.T) {
+	var m = map[string]int{"aaa": 1, "ccc": 2}
+	verifyPredicate(t, pr(impl.HasKeys("aaa", "ccc")), expectation{value: m, pass: true})
+	verifyPredicate(t, pr(impl.HasKeys("aaa", "bbb")), expectation{value: m, pass: false})
+}
+
+func TestHasKeysContext(t *testing.T) {
+	var _, p = impl.HasKeys("aaa", "acc", "zzz")
+	_, ctx, _ := p(map[string]int{"aaa": 1, "ccc": 2})
+	if len(ctx) != 2 ||
+		ctx[0].Name != "missing keys" || ctx[0].Value != `"acc", "zzz"` ||
+		ctx[1].Name != "nearest keys" || ctx[1].Value != `"ccc"` {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestHasEntry(t *testing.T) {
+	var m = map[string]int{"aaa": 1, "ccc": 2}
+	verifyPredicate(t, pr(impl.HasEntry("aaa", 1)), expectation{value: m, pass: true})
+	verifyPredicate(t, pr(impl.HasEntry("aaa", 2)), expectation{value: m, pass: false})
+	verifyPredicate(t, pr(impl.HasEntry("bbb", 1)), expectation{value: m, pass: false})
+	verifyPredicate(t, pr(impl.HasEntry("aaa", 1)), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not a map",
+	})
+	verifyPredicate(t, pr(impl.HasEntry("aaa", []int{1})), expectation{
+		value:    m,
+		errorMsg: "value at key \"aaa\" cannot be compared: values of type 'int' and '[]int' are never equal",
+	})
+}
+
+func TestHasEntryContext(t *testing.T) {
+	var _, p = impl.HasEntry("aaa", 2)
+	_, ctx, _ := p(map[string]int{"aaa": 1})
+	if len(ctx) != 1 || ctx[0].Name != `$["aaa"]` || ctx[0].Value != 1 {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestContainsEntries(t *testing.T) {
+	var m = map[string]int{"aaa": 1, "bbb": 2, "ccc": 3}
+	verifyPredicate(t, pr(impl.ContainsEntries(map[string]int{"aaa": 1, "ccc": 3})), expectation{
+		value: m,
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.ContainsEntries(map[string]int{"aaa": 2})), expectation{
+		value: m,
+		pass:  false,
+	})
+	verifyPredicate(t, pr(impl.ContainsEntries(map[string]int{"ddd": 4})), expectation{
+		value: m,
+		pass:  false,
+	})
+	verifyPredicate(t, pr(impl.ContainsEntries([]int{1})), expectation{
+		value:    m,
+		errorMsg: "value of type '[]int' is not a map of expected entries",
+	})
+	verifyPredicate(t, pr(impl.ContainsEntries(map[string]interface{}{"bbb": []int{2}})), expectation{
+		value:    m,
+		errorMsg: "value at key \"bbb\" cannot be compared: values of type 'int' and '[]int' are never equal",
+	})
+}
+
+func TestContainsEntriesContext(t *testing.T) {
+	var _, p = impl.ContainsEntries(map[string]int{"aaa": 2, "bbx": 2, "ccc": 3})
+	_, ctx, _ := p(map[string]int{"aaa": 1, "bbb": 2, "ccc": 3})
+	if len(ctx) != 3 ||
+		ctx[0].Name != "missing keys" || ctx[0].Value != `"bbx"` ||
+		ctx[1].Name != "mismatched values" || ctx[1].Value != `"aaa": 1, expected 2` ||
+		ctx[2].Name != "nearest keys" || ctx[2].Value != `"bbb"` {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
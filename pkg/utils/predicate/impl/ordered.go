@@ -2,7 +2,9 @@ package impl
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
 	"github.com/maargenton/go-testpredicate/pkg/utils/value"
@@ -51,21 +53,201 @@ func IsGreaterOrEqualTo(rhs interface{}) (desc string, f predicate.PredicateFunc
 	return
 }
 
-// IsCloseTo tests if a value is within tolerance of a reference value
+// IsCloseTo tests if a value is within tolerance of a reference value. Both
+// values can be numbers, complex numbers, or sequences, maps and structs of
+// matching shape, compared component by component; see value.MaxDifference()
+// for more details.
 func IsCloseTo(rhs interface{}, tolerance float64) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("{} ≈ %v ± %v", prettyprint.FormatValue(rhs), tolerance)
+	f = closenessPredicate(rhs, value.AbsoluteDifference, tolerance, "difference")
+	return
+}
+
+// IsCloseToRel tests if a value is within a relative tolerance of a reference
+// value, i.e. if `|v - rhs| <= rel * max(|v|, |rhs|)` for every component.
+func IsCloseToRel(rhs interface{}, rel float64) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} ≈ %v ± %v (relative)", prettyprint.FormatValue(rhs), rel)
+	f = closenessPredicate(rhs, value.RelativeDifference, rel, "relative difference")
+	return
+}
+
+// IsWithinULPs tests if a floating point value is within `n` units in the last
+// place of a reference value, i.e. if there are at most `n` representable
+// floating point values between them, for every component.
+func IsWithinULPs(rhs interface{}, n int) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} ≈ %v ± %v ULPs", prettyprint.FormatValue(rhs), n)
+	f = closenessPredicate(rhs, value.ULPDifference, float64(n), "ULP difference")
+	return
+}
+
+// IsBefore tests if a value is strictly before a reference value. Times are
+// compared as instants, ignoring location and monotonic clock reading.
+func IsBefore(rhs interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} before %v", prettyprint.FormatValue(rhs))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
-		delta, err := value.MaxAbsoluteDifference(v, rhs)
-		return delta <= tolerance && err == nil, []predicate.ContextValue{
-			{Name: "difference", Value: delta},
-		}, err
+		order, err := value.CompareOrdered(v, rhs)
+		return order < 0 && err == nil, timeDifferenceContext(v, rhs), err
+	}
+	return
+}
+
+// IsAfter tests if a value is strictly after a reference value. Times are
+// compared as instants, ignoring location and monotonic clock reading.
+func IsAfter(rhs interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} after %v", prettyprint.FormatValue(rhs))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		order, err := value.CompareOrdered(v, rhs)
+		return order > 0 && err == nil, timeDifferenceContext(v, rhs), err
 	}
 	return
 }
 
+// IsBetween tests if a value is between two reference values, with both
+// bounds inclusive.
+func IsBetween(lo, hi interface{}) (desc string, f predicate.PredicateFunc) {
+	return rangePredicate(lo, hi, true, true)
+}
+
+// IsStrictlyBetween tests if a value is between two reference values, with
+// both bounds exclusive.
+func IsStrictlyBetween(lo, hi interface{}) (desc string, f predicate.PredicateFunc) {
+	return rangePredicate(lo, hi, false, false)
+}
+
+// IsInRange tests if a value is within the half-open range defined by two
+// reference values, with the lower bound inclusive and the upper bound
+// exclusive.
+func IsInRange(lo, hi interface{}) (desc string, f predicate.PredicateFunc) {
+	return rangePredicate(lo, hi, true, false)
+}
+
 // Comparison predicates
 // ---------------------------------------------------------------------------
 
+// ---------------------------------------------------------------------------
+// Helper functions for range predicates
+
+func rangePredicate(lo, hi interface{}, loInclusive, hiInclusive bool) (desc string, f predicate.PredicateFunc) {
+	var open, close = "(", ")"
+	if loInclusive {
+		open = "["
+	}
+	if hiInclusive {
+		close = "]"
+	}
+	desc = fmt.Sprintf("{} ∈ %v%v, %v%v", open,
+		prettyprint.FormatValue(lo), prettyprint.FormatValue(hi), close)
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		orderLo, err := value.CompareOrdered(v, lo)
+		if err != nil {
+			return false, nil, err
+		}
+		orderHi, err := value.CompareOrdered(v, hi)
+		if err != nil {
+			return false, nil, err
+		}
+		r = (orderLo > 0 || loInclusive && orderLo == 0) &&
+			(orderHi < 0 || hiInclusive && orderHi == 0)
+		return r, nil, nil
+	}
+	return
+}
+
+// Helper functions for range predicates
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for closeness predicates
+
+// closenessPredicate returns a predicate function that checks that the maximum
+// difference between the components of a value and a reference value does not
+// exceed tolerance, reporting the difference and, for composite values, the
+// keypath where it was found.
+func closenessPredicate(rhs interface{}, metric value.DifferenceMetric, tolerance float64, name string) predicate.PredicateFunc {
+	return func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		delta, keypath, err := value.MaxDifference(v, rhs, metric)
+		ctx = []predicate.ContextValue{
+			{Name: name, Value: delta},
+		}
+		if keypath != "" {
+			ctx = append(ctx, predicate.ContextValue{
+				Name: "at", Value: diff.FormatKeypath(keypath), Pre: true,
+			})
+		}
+		return delta <= tolerance && err == nil, ctx, err
+	}
+}
+
+// Helper functions for closeness predicates
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Time predicates
+
+// IsCloseToTime tests if a time value is within a given duration of a
+// reference time.
+func IsCloseToTime(rhs time.Time, tolerance time.Duration) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} ≈ %v ± %v",
+		prettyprint.FormatValue(rhs), prettyprint.FormatValue(tolerance))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		t, ok := v.(time.Time)
+		if !ok {
+			return false, nil, fmt.Errorf("value of type '%T' is not a time", v)
+		}
+		delta := absDuration(t.Sub(rhs))
+		return delta <= tolerance, []predicate.ContextValue{
+			{Name: "difference", Value: delta},
+		}, nil
+	}
+	return
+}
+
+// IsWithin tests if a time value is within a given duration of the current
+// time, as sampled when the predicate is evaluated.
+func IsWithin(tolerance time.Duration) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} ≈ now ± %v", prettyprint.FormatValue(tolerance))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		t, ok := v.(time.Time)
+		if !ok {
+			return false, nil, fmt.Errorf("value of type '%T' is not a time", v)
+		}
+		delta := absDuration(time.Since(t))
+		return delta <= tolerance, []predicate.ContextValue{
+			{Name: "difference", Value: delta},
+		}, nil
+	}
+	return
+}
+
+// Time predicates
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for time predicates
+
+// timeDifferenceContext returns a context value with the signed difference
+// between two time values, or nil if either value is not a time.
+func timeDifferenceContext(v, rhs interface{}) []predicate.ContextValue {
+	t1, ok1 := v.(time.Time)
+	t2, ok2 := rhs.(time.Time)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	return []predicate.ContextValue{
+		{Name: "difference", Value: t1.Sub(t2)},
+	}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Helper functions for time predicates
+// ---------------------------------------------------------------------------
+
 // ---------------------------------------------------------------------------
 // Aliases
 
@@ -1,7 +1,11 @@
 package impl_test
 
 import (
+	"math"
+	"math/big"
+	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
 )
@@ -96,4 +100,188 @@ func TestIsCloseTo(t *testing.T) {
 		pass:     false,
 		errorMsg: " value of type 'string' cannot be converted to float",
 	})
+	verifyPredicate(t, pr(impl.IsCloseTo(int64(1<<60), 10)), expectation{
+		value: int64(1<<60 + 1), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseTo(time.Duration(1<<60), 10)), expectation{
+		value: time.Duration(1<<60 + 11), pass: false,
+	})
+}
+
+func TestIsCloseToComposite(t *testing.T) {
+	type point struct{ X, Y float64 }
+	verifyPredicate(t, pr(impl.IsCloseTo(point{1, 2}, 0.1)), expectation{
+		value: point{1.05, 1.95}, pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseTo(point{1, 2}, 0.1)), expectation{
+		value: point{1.05, 2.5}, pass: false,
+	})
+	verifyPredicate(t, pr(impl.IsCloseTo(map[string]float64{"a": 1}, 0.1)), expectation{
+		value: map[string]float64{"a": 1.01}, pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseTo(1+1i, 0.1)), expectation{
+		value: 1.05 + 1.05i, pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseTo(math.NaN(), 0.1)), expectation{
+		value: math.NaN(), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseTo(1.0, 0.1)), expectation{
+		value: math.NaN(), pass: false,
+	})
+}
+
+func TestIsCloseToContext(t *testing.T) {
+	type point struct{ X, Y float64 }
+	_, p := impl.IsCloseTo([]point{{1, 2}, {3, 4}}, 0.1)
+	_, ctx, _ := p([]point{{1, 2}, {3.5, 4}})
+	if len(ctx) != 2 ||
+		ctx[0].Name != "difference" || ctx[0].Value != 0.5 ||
+		ctx[1].Name != "at" || ctx[1].Value != ".[1].X" {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestIsCloseToRel(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsCloseToRel(1000, 0.01)), expectation{value: 1005, pass: true})
+	verifyPredicate(t, pr(impl.IsCloseToRel(1000, 0.01)), expectation{value: 1020, pass: false})
+	verifyPredicate(t, pr(impl.IsCloseToRel([]float64{1e-9, 1e9}, 0.01)), expectation{
+		value: []float64{1.001e-9, 1.001e9}, pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseToRel(0, 0.01)), expectation{value: 0.0, pass: true})
+	verifyPredicate(t, pr(impl.IsCloseToRel(1000, 0.01)), expectation{
+		value:    "1000",
+		errorMsg: "value of type 'string' cannot be converted to float",
+	})
+}
+
+func TestIsWithinULPs(t *testing.T) {
+	var a, b = 0.1, 0.2
+	var x = a + b
+	verifyPredicate(t, pr(impl.IsWithinULPs(0.3, 1)), expectation{value: x, pass: true})
+	verifyPredicate(t, pr(impl.IsWithinULPs(0.3, 0)), expectation{value: x, pass: false})
+	verifyPredicate(t, pr(impl.IsWithinULPs(float32(1), 2)), expectation{
+		value: math.Nextafter32(math.Nextafter32(1, 2), 2), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsWithinULPs(math.Inf(1), 4)), expectation{
+		value: math.MaxFloat64, pass: false,
+	})
+}
+
+func TestIsBefore(t *testing.T) {
+	var ref = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	verifyPredicate(t, pr(impl.IsBefore(ref)), expectation{value: ref.Add(-time.Second), pass: true})
+	verifyPredicate(t, pr(impl.IsBefore(ref)), expectation{value: ref, pass: false})
+	verifyPredicate(t, pr(impl.IsBefore(ref)), expectation{value: ref.Add(time.Second), pass: false})
+	verifyPredicate(t, pr(impl.IsBefore(time.Second)), expectation{value: time.Millisecond, pass: true})
+	verifyPredicate(t, pr(impl.IsBefore(ref)), expectation{
+		value:    "2020",
+		pass:     false,
+		errorMsg: "values of type 'string' and 'time.Time' are not order comparable",
+	})
+
+	_, f := impl.IsBefore(ref)
+	_, ctx, _ := f(ref.Add(1500 * time.Millisecond))
+	if len(ctx) != 1 || ctx[0].Name != "difference" || ctx[0].Value != 1500*time.Millisecond {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestIsAfter(t *testing.T) {
+	var ref = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	verifyPredicate(t, pr(impl.IsAfter(ref)), expectation{value: ref.Add(-time.Second), pass: false})
+	verifyPredicate(t, pr(impl.IsAfter(ref)), expectation{value: ref, pass: false})
+	verifyPredicate(t, pr(impl.IsAfter(ref)), expectation{value: ref.Add(time.Second), pass: true})
+	verifyPredicate(t, pr(impl.IsAfter(ref.In(time.FixedZone("X", 3600)))), expectation{
+		value: ref, pass: false,
+	})
+}
+
+func TestIsBetween(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsBetween(1, 3)), expectation{value: 0, pass: false})
+	verifyPredicate(t, pr(impl.IsBetween(1, 3)), expectation{value: 1, pass: true})
+	verifyPredicate(t, pr(impl.IsBetween(1, 3)), expectation{value: 3, pass: true})
+	verifyPredicate(t, pr(impl.IsBetween(1, 3)), expectation{value: 3.5, pass: false})
+	verifyPredicate(t, pr(impl.IsBetween(time.Second, time.Minute)), expectation{
+		value: 30 * time.Second, pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsBetween(1, "3")), expectation{
+		value:    2,
+		pass:     false,
+		errorMsg: "values of type 'int' and 'string' are not order comparable",
+	})
+}
+
+func TestIsStrictlyBetween(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsStrictlyBetween(1, 3)), expectation{value: 1, pass: false})
+	verifyPredicate(t, pr(impl.IsStrictlyBetween(1, 3)), expectation{value: 2, pass: true})
+	verifyPredicate(t, pr(impl.IsStrictlyBetween(1, 3)), expectation{value: 3, pass: false})
+	verifyPredicate(t, pr(impl.IsStrictlyBetween(big.NewInt(1), big.NewInt(3))), expectation{
+		value: big.NewInt(2), pass: true,
+	})
+}
+
+func TestIsInRange(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsInRange(1, 3)), expectation{value: 0, pass: false})
+	verifyPredicate(t, pr(impl.IsInRange(1, 3)), expectation{value: 1, pass: true})
+	verifyPredicate(t, pr(impl.IsInRange(1, 3)), expectation{value: 2.5, pass: true})
+	verifyPredicate(t, pr(impl.IsInRange(1, 3)), expectation{value: 3, pass: false})
+	verifyPredicate(t, pr(impl.IsInRange(
+		netip.MustParseAddr("10.0.0.0"), netip.MustParseAddr("10.0.1.0"))), expectation{
+		value: netip.MustParseAddr("10.0.0.42"), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsInRange(big.NewInt(1), big.NewInt(3))), expectation{
+		value:    2,
+		pass:     false,
+		errorMsg: "values of type 'int' and '*big.Int' are not order comparable",
+	})
+}
+
+func TestRangeDescription(t *testing.T) {
+	if desc, _ := impl.IsBetween(1, 3); desc != "{} ∈ [1, 3]" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	if desc, _ := impl.IsStrictlyBetween(1, 3); desc != "{} ∈ (1, 3)" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	if desc, _ := impl.IsInRange(1, 3); desc != "{} ∈ [1, 3)" {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+}
+
+func TestOrderedWithCompareMethods(t *testing.T) {
+	verifyPredicate(t, pr(impl.Lt(big.NewInt(10))), expectation{value: big.NewInt(9), pass: true})
+	verifyPredicate(t, pr(impl.Ge(big.NewRat(1, 2))), expectation{value: big.NewRat(2, 4), pass: true})
+	verifyPredicate(t, pr(impl.Gt(big.NewFloat(1.5))), expectation{value: big.NewFloat(1.25), pass: false})
+}
+
+func TestIsCloseToTime(t *testing.T) {
+	var ref = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	verifyPredicate(t, pr(impl.IsCloseToTime(ref, 2*time.Second)), expectation{
+		value: ref.Add(2 * time.Second), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseToTime(ref, 2*time.Second)), expectation{
+		value: ref.Add(-2 * time.Second), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsCloseToTime(ref, 2*time.Second)), expectation{
+		value: ref.Add(-3 * time.Second), pass: false,
+	})
+	verifyPredicate(t, pr(impl.IsCloseToTime(ref, 2*time.Second)), expectation{
+		value:    123,
+		pass:     false,
+		errorMsg: "value of type 'int' is not a time",
+	})
+}
+
+func TestIsWithin(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsWithin(time.Minute)), expectation{
+		value: time.Now(), pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsWithin(time.Minute)), expectation{
+		value: time.Now().Add(-time.Hour), pass: false,
+	})
+	verifyPredicate(t, pr(impl.IsWithin(time.Minute)), expectation{
+		value:    "now",
+		pass:     false,
+		errorMsg: "value of type 'string' is not a time",
+	})
 }
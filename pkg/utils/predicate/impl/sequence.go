@@ -3,6 +3,7 @@ package impl
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
@@ -14,11 +15,14 @@ import (
 
 // Length is a transformation predicate that extract the length of a value for
 // further evaluation. It applies to values of type String, Array, Slice, Map,
-// and Channel.
+// and Channel, and to iterators, which are collected first.
 func Length() (desc string, f predicate.TransformFunc) {
 	desc = "length({})"
 	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
-		vv := reflect.ValueOf(v)
+		vv, err := iteratorValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
 		switch vv.Kind() {
 		case reflect.Array, reflect.Slice, reflect.Map,
 			reflect.Chan, reflect.String:
@@ -59,11 +63,14 @@ func Capacity() (desc string, f predicate.TransformFunc) {
 // ---------------------------------------------------------------------------
 // Evaluation predicates on sequences
 
-// IsEmpty tests if a sequence or container is empty.
+// IsEmpty tests if a sequence, container or iterator is empty.
 func IsEmpty() (desc string, f predicate.PredicateFunc) {
 	desc = "{} is empty"
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
-		vv := reflect.ValueOf(v)
+		vv, err := iteratorValue(v)
+		if err != nil {
+			return false, nil, err
+		}
 		switch vv.Kind() {
 		case reflect.Array, reflect.Slice, reflect.Map,
 			reflect.Chan, reflect.String:
@@ -78,11 +85,14 @@ func IsEmpty() (desc string, f predicate.PredicateFunc) {
 	return
 }
 
-// IsNotEmpty tests if a sequence or container is not empty.
+// IsNotEmpty tests if a sequence, container or iterator is not empty.
 func IsNotEmpty() (desc string, f predicate.PredicateFunc) {
 	desc = "{} is not empty"
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
-		vv := reflect.ValueOf(v)
+		vv, err := iteratorValue(v)
+		if err != nil {
+			return false, nil, err
+		}
 		switch vv.Kind() {
 		case reflect.Array, reflect.Slice, reflect.Map,
 			reflect.Chan, reflect.String:
@@ -98,10 +108,15 @@ func IsNotEmpty() (desc string, f predicate.PredicateFunc) {
 }
 
 // StartsWith tests if a sequence value starts with the given sequence, and can
-// be applied to  strings, arrays and slices.
+// be applied to  strings, arrays and slices, as well as iterators, maps and
+// channels, which are collected first.
 func StartsWith(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("{} starts with %v", prettyprint.FormatValue(rhs))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		v, err = sequenceValue(v)
+		if err != nil {
+			return false, nil, err
+		}
 		v1, v2 := reflect.ValueOf(v), reflect.ValueOf(rhs)
 		if err := value.PreCheckSubsequence(v1, v2); err != nil {
 			return false, nil, err
@@ -113,16 +128,24 @@ func StartsWith(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 				l1, l2)
 		}
 		i := value.IndexOfSubsequence(v1.Slice(0, l2), v2)
-		return i == 0, nil, nil
+		if i != 0 {
+			ctx = textPrefixDiffContext(v, rhs, true)
+		}
+		return i == 0, ctx, nil
 	}
 	return
 }
 
 // Contains tests if a sequence value contains the given sequence, and can
-// be applied to  strings, arrays and slices.
+// be applied to  strings, arrays and slices, as well as iterators, maps and
+// channels, which are collected first.
 func Contains(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("{} contains %v", prettyprint.FormatValue(rhs))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		v, err = sequenceValue(v)
+		if err != nil {
+			return false, nil, err
+		}
 		v1, v2 := reflect.ValueOf(v), reflect.ValueOf(rhs)
 		if err := value.PreCheckSubsequence(v1, v2); err != nil {
 			return false, nil, err
@@ -140,10 +163,15 @@ func Contains(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 }
 
 // EndsWith tests if a sequence value ends with the given sequence, and can
-// be applied to  strings, arrays and slices.
+// be applied to  strings, arrays and slices, as well as iterators, maps and
+// channels, which are collected first.
 func EndsWith(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("{} ends with %v", prettyprint.FormatValue(rhs))
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		v, err = sequenceValue(v)
+		if err != nil {
+			return false, nil, err
+		}
 		v1, v2 := reflect.ValueOf(v), reflect.ValueOf(rhs)
 		if err := value.PreCheckSubsequence(v1, v2); err != nil {
 			return false, nil, err
@@ -155,7 +183,10 @@ func EndsWith(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 				l1, l2)
 		}
 		i := value.IndexOfSubsequence(v1.Slice(l1-l2, l1), v2)
-		return i == 0, nil, nil
+		if i != 0 {
+			ctx = textPrefixDiffContext(v, rhs, false)
+		}
+		return i == 0, ctx, nil
 	}
 	return
 }
@@ -180,3 +211,62 @@ func HasSuffix(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 
 // Aliases
 // ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for sequence predicates
+
+// iteratorValue returns the reflected value of a value, first materializing the
+// elements of iterators into a slice.
+func iteratorValue(v interface{}) (reflect.Value, error) {
+	if value.IsIterator(v) {
+		c, err := value.Collect(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = c
+	}
+	return reflect.ValueOf(v), nil
+}
+
+// sequenceValue materializes the elements of iterators, maps and channels
+// into a slice, so that they can be evaluated as a sequence.
+func sequenceValue(v interface{}) (interface{}, error) {
+	if value.IsIterable(v) {
+		return value.Collect(v)
+	}
+	return v, nil
+}
+
+// textPrefixDiffContext returns a unified diff between a multi-line text
+// expected prefix or suffix, and the matching number of leading or trailing
+// lines of the text value.
+func textPrefixDiffContext(v, rhs interface{}, prefix bool) []predicate.ContextValue {
+	s, ok := textValue(v)
+	if !ok {
+		return nil
+	}
+	expected, ok := textValue(rhs)
+	if !ok {
+		return nil
+	}
+
+	var n = strings.Count(expected, "\n")
+	if !strings.HasSuffix(expected, "\n") {
+		n++
+	}
+	var lines = strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if n < len(lines) {
+		if prefix {
+			lines = lines[:n]
+		} else {
+			lines = lines[len(lines)-n:]
+		}
+	}
+	return textDiffContext(strings.Join(lines, ""), expected)
+}
+
+// Helper functions for sequence predicates
+// ---------------------------------------------------------------------------
@@ -1,6 +1,8 @@
 package impl_test
 
 import (
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
@@ -122,3 +124,61 @@ func TestEndsWith(t *testing.T) {
 		errorMsg: "sequence of length 3 is too short to contain a subsequence of length 6",
 	})
 }
+
+func TestStartsWithAndEndsWithReportTextDiff(t *testing.T) {
+	_, f := impl.StartsWith("aaa\nbbb\n")
+	_, ctx, _ := f("aaa\nbxb\nccc\n")
+	expected := "--- expected\n+++ value\n@@ -1,2 +1,2 @@\n" +
+		" aaa\n-bbb\n? ^\n+bxb\n? ^"
+	if len(ctx) != 1 || ctx[0].Name != "diff" || ctx[0].Value != expected {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+
+	_, f = impl.EndsWith("bbb\nccc\n")
+	_, ctx, _ = f("aaa\nbbb\ncxc\n")
+	expected = "--- expected\n+++ value\n@@ -1,2 +1,2 @@\n" +
+		" bbb\n-ccc\n? ^\n+cxc\n? ^"
+	if len(ctx) != 1 || ctx[0].Name != "diff" || ctx[0].Value != expected {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestSequenceOverIterables(t *testing.T) {
+	verifyTransform(t, tr(impl.Length()), expectation{
+		value:  slices.Values([]int{1, 2, 3}),
+		result: 3,
+	})
+	verifyTransform(t, tr(impl.Length()), expectation{
+		value:  maps.All(map[string]int{"a": 1}),
+		result: 1,
+	})
+	verifyPredicate(t, pr(impl.IsEmpty()), expectation{
+		value: slices.Values([]int{}),
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsNotEmpty()), expectation{
+		value: slices.Values([]int{1}),
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.Contains([]int{2, 3})), expectation{
+		value: slices.Values([]int{1, 2, 3, 4}),
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.StartsWith([]int{1, 2})), expectation{
+		value: slices.Values([]int{1, 2, 3, 4}),
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.EndsWith([]int{1, 2})), expectation{
+		value: slices.Values([]int{1, 2, 3, 4}),
+		pass:  false,
+	})
+
+	var ch = make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+	verifyPredicate(t, pr(impl.Contains([]string{"b"})), expectation{
+		value: ch,
+		pass:  true,
+	})
+}
@@ -112,3 +112,38 @@ func IsSupersetOf(rhs interface{}) (desc string, f predicate.PredicateFunc) {
 	}
 	return
 }
+
+// IsPermutationOf tests if two containers contain the same elements with the
+// same multiplicity, independently of order. Elements that are not comparable
+// (e.g. slices or structs containing slices) are matched pairwise.
+func IsPermutationOf(rhs interface{}) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} is a permutation of %v", prettyprint.FormatValue(rhs))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		extra, missing, err := value.CompareMultisets(v, rhs)
+		if err != nil {
+			return
+		}
+
+		r = len(extra) == 0 && len(missing) == 0
+		if !r {
+			ctx = []predicate.ContextValue{
+				{Name: "extra values", Value: value.FormatElementCounts(extra), Pre: true},
+				{Name: "missing values", Value: value.FormatElementCounts(missing), Pre: true},
+			}
+		}
+		return
+	}
+	return
+}
+
+// ---------------------------------------------------------------------------
+// Aliases
+
+// ElementsMatch tests if two containers contain the same elements with the
+// same multiplicity, independently of order.
+func ElementsMatch(rhs interface{}) (desc string, f predicate.PredicateFunc) {
+	return IsPermutationOf(rhs)
+}
+
+// Aliases
+// ---------------------------------------------------------------------------
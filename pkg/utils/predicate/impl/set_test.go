@@ -102,3 +102,41 @@ func TestIsSupersetOf(t *testing.T) {
 		errorMsg: "value of type 'int' is not an indexable collection",
 	})
 }
+
+func TestIsPermutationOf(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsPermutationOf([]int{1, 2, 2, 3})), expectation{
+		value: []int{2, 3, 2, 1},
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsPermutationOf([]string{"a", "b", "b"})), expectation{
+		value: []string{"a", "a", "b"},
+		pass:  false,
+	})
+	verifyPredicate(t, pr(impl.ElementsMatch([][]int{{1}, {2}})), expectation{
+		value: [][]int{{2}, {1}},
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsPermutationOf([]*struct{ A int }{{1}, {2}})), expectation{
+		value: []*struct{ A int }{{2}, {1}},
+		pass:  true,
+	})
+
+	verifyPredicate(t, pr(impl.IsPermutationOf(123)), expectation{
+		value:    []int{3, 1, 5},
+		errorMsg: "value of type 'int' is not an indexable collection",
+	})
+	verifyPredicate(t, pr(impl.IsPermutationOf([]int{1, 2, 3})), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' is not an indexable collection",
+	})
+}
+
+func TestIsPermutationOfContext(t *testing.T) {
+	var _, p = impl.IsPermutationOf([]string{"a", "b", "b", "b", "c"})
+	_, ctx, _ := p([]string{"a", "a", "b", "d"})
+	if len(ctx) != 2 ||
+		ctx[0].Value != `"a", "d"` ||
+		ctx[1].Value != `"b" (×2), "c"` {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
 )
 
 // (desc string, f PredicateFunc)
 
-// Matches tests if a string matches a regular expression
-func Matches(re string) (desc string, f predicate.PredicateFunc) {
+// Matches tests if a string matches a regular expression, specified either as
+// a string or as a pre-compiled `*regexp.Regexp`.
+func Matches(re any) (desc string, f predicate.PredicateFunc) {
 	desc = fmt.Sprintf("{} =~ /%v/", re)
 	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
 		s, ok := v.(string)
@@ -19,11 +23,82 @@ func Matches(re string) (desc string, f predicate.PredicateFunc) {
 			return false, nil, fmt.Errorf(
 				"value of type '%T' cannot be matched against a regexp", v)
 		}
-		m, err := regexp.MatchString(re, s)
+		rx, err := compileRegexp("Matches", re)
 		if err != nil {
-			return false, nil, fmt.Errorf("failed to compile regexp: %w", err)
+			return false, nil, err
 		}
-		return m, nil, nil
+		return rx.MatchString(s), nil, nil
+	}
+	return
+}
+
+// EqualFold tests if a string is equal to the specified string under Unicode
+// case-folding.
+func EqualFold(rhs string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} == %v (ignoring case)", prettyprint.FormatValue(rhs))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return false, nil, fmt.Errorf(
+				"value of type '%T' cannot be compared to a string", v)
+		}
+		return strings.EqualFold(s, rhs), nil, nil
+	}
+	return
+}
+
+// IsEqualIgnoringWhitespace tests if a string is equal to the specified string
+// once all whitespace characters are removed from both.
+func IsEqualIgnoringWhitespace(rhs string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} == %v (ignoring whitespace)", prettyprint.FormatValue(rhs))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return false, nil, fmt.Errorf(
+				"value of type '%T' cannot be compared to a string", v)
+		}
+		return removeWhitespace(s) == removeWhitespace(rhs), nil, nil
+	}
+	return
+}
+
+// ContainsLine tests if a multi-line string contains a line that is exactly
+// equal to the specified string, regardless of the newline convention.
+func ContainsLine(line string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} contains line %v", prettyprint.FormatValue(line))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return false, nil, fmt.Errorf(
+				"value of type '%T' cannot be split into lines", v)
+		}
+		for _, l := range splitLines(s) {
+			if l == line {
+				return true, nil, nil
+			}
+		}
+		return false, nil, nil
+	}
+	return
+}
+
+// MatchesGlob tests if a string matches a glob pattern, where `*` matches any
+// sequence of characters including newlines and path separators, `?` matches
+// any single character, `[...]` matches a character class, negated with a
+// leading `!` or `^`, and `\` escapes the next character.
+func MatchesGlob(pattern string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} matches glob %v", prettyprint.FormatValue(pattern))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return false, nil, fmt.Errorf(
+				"value of type '%T' cannot be matched against a glob pattern", v)
+		}
+		re, err := globRegexp(pattern)
+		if err != nil {
+			return false, nil, err
+		}
+		return re.MatchString(s), nil, nil
 	}
 	return
 }
@@ -80,7 +155,337 @@ func ToUpper() (desc string, f predicate.TransformFunc) {
 	return
 }
 
+// TrimSpace is a transformation predicate that removes all leading and
+// trailing whitespace from a string.
+func TrimSpace() (desc string, f predicate.TransformFunc) {
+	desc = "TrimSpace({})"
+	f = stringTransform("trimmed", strings.TrimSpace)
+	return
+}
+
+// NormalizeNewlines is a transformation predicate that converts all Windows
+// (`\r\n`) and classic Mac (`\r`) newlines of a string into `\n`.
+func NormalizeNewlines() (desc string, f predicate.TransformFunc) {
+	desc = "NormalizeNewlines({})"
+	f = stringTransform("normalized", normalizeNewlines)
+	return
+}
+
+// NormalizeWhitespace is a transformation predicate that trims a string and
+// collapses every sequence of whitespace characters, including newlines, into
+// a single space.
+func NormalizeWhitespace() (desc string, f predicate.TransformFunc) {
+	desc = "NormalizeWhitespace({})"
+	f = stringTransform("normalized", func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	})
+	return
+}
+
+// Dedent is a transformation predicate that removes from every line of a
+// multi-line string the leading whitespace common to all non-blank lines.
+// Lines containing only whitespace are emptied.
+func Dedent() (desc string, f predicate.TransformFunc) {
+	desc = "Dedent({})"
+	f = stringTransform("dedented", dedent)
+	return
+}
+
+// Lines is a transformation predicate that splits a string into lines,
+// regardless of the newline convention. A trailing newline does not produce
+// an additional empty line.
+func Lines() (desc string, f predicate.TransformFunc) {
+	desc = "Lines({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"value of type '%T' cannot be split into lines", v)
+		}
+		lines := splitLines(s)
+		return lines, []predicate.ContextValue{
+			{Name: "lines", Value: lines},
+		}, nil
+	}
+	return
+}
+
+// Words is a transformation predicate that splits a string into words
+// separated by any sequence of whitespace characters.
+func Words() (desc string, f predicate.TransformFunc) {
+	desc = "Words({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"value of type '%T' cannot be split into words", v)
+		}
+		words := strings.Fields(s)
+		return words, []predicate.ContextValue{
+			{Name: "words", Value: words},
+		}, nil
+	}
+	return
+}
+
+// Split is a transformation predicate that splits a string into all the
+// substrings separated by `sep`.
+func Split(sep string) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("Split({}, %v)", prettyprint.FormatValue(sep))
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"value of type '%T' cannot be split", v)
+		}
+		fragments := strings.Split(s, sep)
+		return fragments, []predicate.ContextValue{
+			{Name: "fragments", Value: fragments},
+		}, nil
+	}
+	return
+}
+
+// RuneCount is a transformation predicate that returns the number of Unicode
+// code points in a string, as opposed to `Length()` that counts bytes.
+func RuneCount() (desc string, f predicate.TransformFunc) {
+	desc = "RuneCount({})"
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"value of type '%T' does not have a rune count", v)
+		}
+		n := utf8.RuneCountInString(s)
+		return n, []predicate.ContextValue{
+			{Name: "rune count", Value: n, Pre: true},
+		}, nil
+	}
+	return
+}
+
+// MatchGroups is a transformation predicate that matches a string against a
+// regular expression, specified either as a string or as a pre-compiled
+// `*regexp.Regexp`, and returns the `[]string` of its capture groups for the
+// first match, excluding the text of the whole match. The transformation
+// fails if the string does not match.
+func MatchGroups(re any) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("MatchGroups({}, /%v/)", re)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		_, m, ctx, err := firstMatch("MatchGroups", re, v)
+		if err != nil {
+			return nil, ctx, err
+		}
+		groups := m[1:]
+		return groups, []predicate.ContextValue{
+			{Name: "groups", Value: groups},
+		}, nil
+	}
+	return
+}
+
+// NamedGroups is a transformation predicate that matches a string against a
+// regular expression, specified either as a string or as a pre-compiled
+// `*regexp.Regexp`, and returns a `map[string]string` of its named capture
+// groups for the first match. The transformation fails if the string does not
+// match.
+func NamedGroups(re any) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("NamedGroups({}, /%v/)", re)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		rx, m, ctx, err := firstMatch("NamedGroups", re, v)
+		if err != nil {
+			return nil, ctx, err
+		}
+		groups := map[string]string{}
+		for i, name := range rx.SubexpNames() {
+			if i > 0 && name != "" {
+				groups[name] = m[i]
+			}
+		}
+		return groups, []predicate.ContextValue{
+			{Name: "groups", Value: groups},
+		}, nil
+	}
+	return
+}
+
+// FindAll is a transformation predicate that returns the `[]string` of all
+// the successive non-overlapping matches of a regular expression in a string,
+// specified either as a string or as a pre-compiled `*regexp.Regexp`.
+func FindAll(re any) (desc string, f predicate.TransformFunc) {
+	desc = fmt.Sprintf("FindAll({}, /%v/)", re)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"value of type '%T' cannot be matched against a regexp", v)
+		}
+		rx, err := compileRegexp("FindAll", re)
+		if err != nil {
+			return nil, nil, err
+		}
+		matches := rx.FindAllString(s, -1)
+		if matches == nil {
+			matches = []string{}
+		}
+		return matches, []predicate.ContextValue{
+			{Name: "matches", Value: matches},
+		}, nil
+	}
+	return
+}
+
 // ToGoString returns a predicate that checks
 // func ToGoString(p predicate.T) predicate.T {
 // 	return testpredicate.MakeUnimplemented()
 // }
+
+// Transformation predicates on strings or producing strings
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for string predicates
+
+// compileRegexp returns the regexp for an argument given either as a string
+// or as a `*regexp.Regexp`.
+func compileRegexp(name string, re any) (*regexp.Regexp, error) {
+	switch re := re.(type) {
+	case *regexp.Regexp:
+		return re, nil
+	case string:
+		rx, err := regexp.Compile(re)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile regexp: %w", err)
+		}
+		return rx, nil
+	}
+	return nil, fmt.Errorf(
+		"invalid argument of type '%T' for '%v()' predicate", re, name)
+}
+
+// firstMatch returns the compiled regexp and the submatches of its first match
+// in a string value, or an error with the pattern and input in context if the
+// string does not match.
+func firstMatch(name string, re any, v interface{}) (*regexp.Regexp, []string, []predicate.ContextValue, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf(
+			"value of type '%T' cannot be matched against a regexp", v)
+	}
+	rx, err := compileRegexp(name, re)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	m := rx.FindStringSubmatch(s)
+	if m == nil {
+		return nil, nil, []predicate.ContextValue{
+			{Name: "pattern", Value: fmt.Sprintf("/%v/", rx), Pre: true},
+			{Name: "input", Value: s},
+		}, fmt.Errorf("value does not match /%v/", rx)
+	}
+	return rx, m, nil, nil
+}
+
+// stringTransform returns a transformation applying a string function to
+// string values, and reporting the result in context as the named value.
+func stringTransform(name string, fn func(string) string) predicate.TransformFunc {
+	return func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"value of type '%T' cannot be %v", v, name)
+		}
+		s = fn(s)
+		return s, []predicate.ContextValue{
+			{Name: name, Value: s},
+		}, nil
+	}
+}
+
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(normalizeNewlines(s), "\n")
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, "\n")
+}
+
+func removeWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func dedent(s string) string {
+	var lines = strings.Split(s, "\n")
+	var prefix string
+	var first = true
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			lines[i] = ""
+			continue
+		}
+		indent := l[:len(l)-len(strings.TrimLeftFunc(l, unicode.IsSpace))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, l := range lines {
+		lines[i] = strings.TrimPrefix(l, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// globRegexp converts a glob pattern into an anchored regular expression.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			if i+1 == len(pattern) {
+				return nil, fmt.Errorf("invalid glob pattern '%v': trailing escape", pattern)
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid glob pattern '%v': unterminated '['", pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern '%v': %w", pattern, err)
+	}
+	return re, nil
+}
+
+// Helper functions for string predicates
+// ---------------------------------------------------------------------------
@@ -1,6 +1,7 @@
 package impl_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
@@ -17,6 +18,75 @@ func TestMatches(t *testing.T) {
 		value:    "123",
 		errorMsg: "failed to compile regexp:",
 	})
+	verifyPredicate(t, pr(impl.Matches(regexp.MustCompile(`^\d+$`))), expectation{
+		value: "123", pass: true,
+	})
+	verifyPredicate(t, pr(impl.Matches(regexp.MustCompile(`^\d+$`))), expectation{
+		value: "123a", pass: false,
+	})
+	verifyPredicate(t, pr(impl.Matches(123)), expectation{
+		value:    "123",
+		errorMsg: "invalid argument of type 'int' for 'Matches()' predicate",
+	})
+}
+
+func TestMatchGroups(t *testing.T) {
+	verifyTransform(t, tr(impl.MatchGroups(`id=(\d+) user=(\w+)`)), expectation{
+		value:  "request id=42 user=alice id=43 user=bob",
+		result: []string{"42", "alice"},
+	})
+	verifyTransform(t, tr(impl.MatchGroups(regexp.MustCompile(`id=\d+`))), expectation{
+		value:  "id=42",
+		result: []string{},
+	})
+	verifyTransform(t, tr(impl.MatchGroups(`id=(\d+)`)), expectation{
+		value:    "request",
+		errorMsg: "value does not match /id=(\\d+)/",
+	})
+	verifyTransform(t, tr(impl.MatchGroups(`(`)), expectation{
+		value:    "request",
+		errorMsg: "failed to compile regexp:",
+	})
+}
+
+func TestMatchGroupsContext(t *testing.T) {
+	_, f := impl.MatchGroups(`id=(\d+)`)
+	_, ctx, _ := f("request")
+	if len(ctx) != 2 ||
+		ctx[0].Name != "pattern" || ctx[0].Value != `/id=(\d+)/` ||
+		ctx[1].Name != "input" || ctx[1].Value != "request" {
+		t.Errorf("\nUnexpected ctx: %+v", ctx)
+	}
+}
+
+func TestNamedGroups(t *testing.T) {
+	verifyTransform(t, tr(impl.NamedGroups(`id=(?P<id>\d+) (\w+)=(?P<value>\w+)`)), expectation{
+		value:  "request id=42 user=alice",
+		result: map[string]string{"id": "42", "value": "alice"},
+	})
+	verifyTransform(t, tr(impl.NamedGroups(`id=(?P<id>\d+)`)), expectation{
+		value:    "request",
+		errorMsg: "value does not match /id=(?P<id>\\d+)/",
+	})
+	verifyTransform(t, tr(impl.NamedGroups(`id=(?P<id>\d+)`)), expectation{
+		value:    42,
+		errorMsg: "value of type 'int' cannot be matched against a regexp",
+	})
+}
+
+func TestFindAll(t *testing.T) {
+	verifyTransform(t, tr(impl.FindAll(`\d+`)), expectation{
+		value:  "a1 b22 c333",
+		result: []string{"1", "22", "333"},
+	})
+	verifyTransform(t, tr(impl.FindAll(regexp.MustCompile(`\d+`))), expectation{
+		value:  "abc",
+		result: []string{},
+	})
+	verifyTransform(t, tr(impl.FindAll(nil)), expectation{
+		value:    "abc",
+		errorMsg: "invalid argument of type '<nil>' for 'FindAll()' predicate",
+	})
 }
 
 func TestToString(t *testing.T) {
@@ -49,3 +119,135 @@ func TestToUpper(t *testing.T) {
 		errorMsg: "value of type 'int' cannot be transformed to uppercase",
 	})
 }
+
+func TestEqualFold(t *testing.T) {
+	verifyPredicate(t, pr(impl.EqualFold("Hello")), expectation{value: "hELLo", pass: true})
+	verifyPredicate(t, pr(impl.EqualFold("Hello")), expectation{value: "hello!", pass: false})
+	verifyPredicate(t, pr(impl.EqualFold("Hello")), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' cannot be compared to a string",
+	})
+}
+
+func TestIsEqualIgnoringWhitespace(t *testing.T) {
+	verifyPredicate(t, pr(impl.IsEqualIgnoringWhitespace("a b\nc")), expectation{
+		value: "  a\tbc \r\n", pass: true,
+	})
+	verifyPredicate(t, pr(impl.IsEqualIgnoringWhitespace("a b c")), expectation{
+		value: "a b d", pass: false,
+	})
+	verifyPredicate(t, pr(impl.IsEqualIgnoringWhitespace("a")), expectation{
+		value:    'a',
+		errorMsg: "value of type 'int32' cannot be compared to a string",
+	})
+}
+
+func TestContainsLine(t *testing.T) {
+	verifyPredicate(t, pr(impl.ContainsLine("b")), expectation{value: "a\r\nb\r\nc", pass: true})
+	verifyPredicate(t, pr(impl.ContainsLine("b")), expectation{value: "a\n b\nc", pass: false})
+	verifyPredicate(t, pr(impl.ContainsLine("")), expectation{value: "a\n\nc", pass: true})
+	verifyPredicate(t, pr(impl.ContainsLine("b")), expectation{
+		value:    []string{"b"},
+		errorMsg: "value of type '[]string' cannot be split into lines",
+	})
+}
+
+func TestMatchesGlob(t *testing.T) {
+	verifyPredicate(t, pr(impl.MatchesGlob("error: * not found")), expectation{
+		value: "error: file /tmp/a.txt not found", pass: true,
+	})
+	verifyPredicate(t, pr(impl.MatchesGlob("file?.[ch]")), expectation{value: "file1.c", pass: true})
+	verifyPredicate(t, pr(impl.MatchesGlob("file?.[!ch]")), expectation{value: "file1.c", pass: false})
+	verifyPredicate(t, pr(impl.MatchesGlob(`a\*b`)), expectation{value: "a*b", pass: true})
+	verifyPredicate(t, pr(impl.MatchesGlob(`a\*b`)), expectation{value: "axb", pass: false})
+	verifyPredicate(t, pr(impl.MatchesGlob("(a+b)")), expectation{value: "(a+b)", pass: true})
+	verifyPredicate(t, pr(impl.MatchesGlob("a*")), expectation{value: "a\nb", pass: true})
+	verifyPredicate(t, pr(impl.MatchesGlob("a[b")), expectation{
+		value:    "ab",
+		errorMsg: "invalid glob pattern 'a[b': unterminated '['",
+	})
+	verifyPredicate(t, pr(impl.MatchesGlob("a*")), expectation{
+		value:    1,
+		errorMsg: "value of type 'int' cannot be matched against a glob pattern",
+	})
+}
+
+func TestTrimSpace(t *testing.T) {
+	verifyTransform(t, tr(impl.TrimSpace()), expectation{
+		value:  " \tabc\n",
+		result: "abc",
+	})
+	verifyTransform(t, tr(impl.TrimSpace()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' cannot be trimmed",
+	})
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	verifyTransform(t, tr(impl.NormalizeNewlines()), expectation{
+		value:  "a\r\nb\rc\n",
+		result: "a\nb\nc\n",
+	})
+}
+
+func TestNormalizeWhitespace(t *testing.T) {
+	verifyTransform(t, tr(impl.NormalizeWhitespace()), expectation{
+		value:  "  a \t b\r\n\nc ",
+		result: "a b c",
+	})
+}
+
+func TestDedent(t *testing.T) {
+	verifyTransform(t, tr(impl.Dedent()), expectation{
+		value:  "\n    func f() {\n        return\n  \n    }\n",
+		result: "\nfunc f() {\n    return\n\n}\n",
+	})
+	verifyTransform(t, tr(impl.Dedent()), expectation{
+		value:  "\t a\n\t\tb",
+		result: " a\n\tb",
+	})
+	verifyTransform(t, tr(impl.Dedent()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' cannot be dedented",
+	})
+}
+
+func TestLines(t *testing.T) {
+	verifyTransform(t, tr(impl.Lines()), expectation{
+		value:  "a\r\nb\n\nc\n",
+		result: []string{"a", "b", "", "c"},
+	})
+	verifyTransform(t, tr(impl.Lines()), expectation{
+		value:  "",
+		result: []string{},
+	})
+}
+
+func TestWords(t *testing.T) {
+	verifyTransform(t, tr(impl.Words()), expectation{
+		value:  " the quick\tbrown\nfox ",
+		result: []string{"the", "quick", "brown", "fox"},
+	})
+}
+
+func TestSplit(t *testing.T) {
+	verifyTransform(t, tr(impl.Split(",")), expectation{
+		value:  "a,b,,c",
+		result: []string{"a", "b", "", "c"},
+	})
+	verifyTransform(t, tr(impl.Split(",")), expectation{
+		value:    []byte("a,b"),
+		errorMsg: "value of type '[]uint8' cannot be split",
+	})
+}
+
+func TestRuneCount(t *testing.T) {
+	verifyTransform(t, tr(impl.RuneCount()), expectation{
+		value:  "héllo",
+		result: 5,
+	})
+	verifyTransform(t, tr(impl.RuneCount()), expectation{
+		value:    123,
+		errorMsg: "value of type 'int' does not have a rune count",
+	})
+}
@@ -2,8 +2,12 @@ package impl
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/maargenton/go-testpredicate/pkg/utils/diff"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
 	"github.com/maargenton/go-testpredicate/pkg/utils/value"
 )
 
@@ -11,13 +15,143 @@ import (
 // value from a map, identified by the given `keypath`. See value.Field() for
 // more details.
 func Field(keypath string) (desc string, f predicate.TransformFunc) {
-	desc = fmt.Sprintf("{}.%v", keypath)
+	desc = "{}" + keypathSuffix(keypath)
 	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
 		r = value.Field(v, keypath)
 		ctx = []predicate.ContextValue{
-			{Name: fmt.Sprintf("$.%v", keypath), Value: r},
+			{Name: "$" + keypathSuffix(keypath), Value: r},
 		}
 		return
 	}
 	return
 }
+
+// StrictField is a transformation predicate similar to Field, but that fails
+// with a descriptive error when any part of the `keypath` cannot be resolved,
+// e.g. a misspelled field name, a missing map key or an out-of-range index.
+// See value.StrictField() for more details.
+func StrictField(keypath string) (desc string, f predicate.TransformFunc) {
+	desc = "{}" + keypathSuffix(keypath)
+	f = func(v interface{}) (r interface{}, ctx []predicate.ContextValue, err error) {
+		r, err = value.StrictField(v, keypath)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx = []predicate.ContextValue{
+			{Name: "$" + keypathSuffix(keypath), Value: r},
+		}
+		return
+	}
+	return
+}
+
+// MatchesFields tests if the fields of a struct or a map, identified by the
+// keypaths used as keys in `fields`, match the associated values. Each
+// expected value is either a literal value compared for equality, or a
+// sub-expression predicate evaluated on the field. Keypaths that cannot be
+// resolved fail with an error; see value.StrictField() for more details.
+func MatchesFields(fields map[string]interface{}) (desc string, f predicate.PredicateFunc) {
+	var keypaths = make([]string, 0, len(fields))
+	for k := range fields {
+		keypaths = append(keypaths, k)
+	}
+	sort.Strings(keypaths)
+
+	var expectations = make([]string, 0, len(fields))
+	for _, k := range keypaths {
+		expectations = append(expectations, formatFieldExpectation(k, fields[k]))
+	}
+	desc = fmt.Sprintf("{} matches fields %v", strings.Join(expectations, ", "))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		var mismatched []string
+		var subctx []predicate.ContextValue
+		for _, k := range keypaths {
+			actual, err := value.StrictField(v, k)
+			if err != nil {
+				return false, nil, err
+			}
+			match, fieldctx, err := matchField(actual, fields[k])
+			if err != nil {
+				return false, nil, fmt.Errorf("field $%v cannot be evaluated: %w",
+					keypathSuffix(k), err)
+			}
+			if !match {
+				mismatched = append(mismatched, fmt.Sprintf("$%v: %v, expected %v",
+					keypathSuffix(k), prettyprint.FormatValue(actual),
+					formatFieldExpectation(k, fields[k])))
+				subctx = append(subctx, fieldctx...)
+			}
+		}
+		if len(mismatched) == 0 {
+			return true, nil, nil
+		}
+		return false, append([]predicate.ContextValue{
+			{Name: "mismatched fields", Value: strings.Join(mismatched, "\n"), Pre: true},
+		}, subctx...), nil
+	}
+	return
+}
+
+// IsEqualIgnoring tests if a value is equal to the specified value, except for
+// the fields identified by the given keypaths. Unexported fields are not
+// compared. See value.Field() for more details about keypaths; keypaths going
+// through sequences apply to all their elements.
+func IsEqualIgnoring(rhs interface{}, keypaths ...string) (desc string, f predicate.PredicateFunc) {
+	desc = fmt.Sprintf("{} == %v ignoring %v",
+		prettyprint.FormatValue(rhs), strings.Join(keypaths, ", "))
+	f = func(v interface{}) (r bool, ctx []predicate.ContextValue, err error) {
+		diffs := diff.CompareIgnoring(v, rhs, keypaths...)
+		if len(diffs) == 0 {
+			return true, nil, nil
+		}
+		return false, []predicate.ContextValue{
+			{Name: "diff", Value: diff.Format(diffs), Pre: true},
+		}, nil
+	}
+	return
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for struct predicates
+
+// matchField returns true if a field value is equal to the expected literal,
+// or passes the expected sub-expression predicate. On mismatch, it also returns
+// the context of the sub-expression, without the expected and actual values
+// already reported by the caller.
+func matchField(v, expected interface{}) (bool, []predicate.ContextValue, error) {
+	if p, ok := expected.(*predicate.Predicate); ok {
+		success, subctx := p.Evaluate(v)
+		subctx, err := evaluationError(subctx)
+		if err != nil || success {
+			return success, nil, err
+		}
+		var ctx []predicate.ContextValue
+		for _, c := range subctx {
+			if c.Name != "expected" && c.Name != "value" {
+				ctx = append(ctx, c)
+			}
+		}
+		return false, ctx, nil
+	}
+	eq, err := value.CompareUnordered(v, expected)
+	return eq && err == nil, nil, nil
+}
+
+func formatFieldExpectation(keypath string, expected interface{}) string {
+	if p, ok := expected.(*predicate.Predicate); ok {
+		return p.FormatDescription(keypath)
+	}
+	return fmt.Sprintf("%v == %v", keypath, prettyprint.FormatValue(expected))
+}
+
+// Helper functions for struct predicates
+// ---------------------------------------------------------------------------
+
+// keypathSuffix formats a keypath to be appended to a value placeholder,
+// omitting the leading dot for keypaths starting with an index.
+func keypathSuffix(keypath string) string {
+	if strings.HasPrefix(keypath, "[") {
+		return keypath
+	}
+	return "." + keypath
+}
@@ -3,6 +3,7 @@ package impl_test
 import (
 	"testing"
 
+	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate/impl"
 )
 
@@ -35,3 +36,140 @@ func TestField(t *testing.T) {
 	}
 
 }
+
+func TestStrictField(t *testing.T) {
+	var v = []account{
+		{ID: 1, Name: "alice", Friends: []account{{Name: "bob"}}},
+	}
+
+	verifyTransform(t, tr(impl.StrictField("[0].Friends[-1].Name")), expectation{
+		value:  v,
+		result: "bob",
+	})
+	verifyTransform(t, tr(impl.StrictField("[0].Nmae")), expectation{
+		value:    v,
+		errorMsg: "no field 'Nmae' on type 'account'; did you mean 'Name'?",
+	})
+	verifyTransform(t, tr(impl.StrictField("[1].Name")), expectation{
+		value:    v,
+		errorMsg: "index 1 out of range for sequence of length 1",
+	})
+
+	var desc, f = impl.StrictField("[0].ID")
+	if desc != "{}[0].ID" {
+		t.Errorf("\nUnexpected description: %v", desc)
+	}
+	_, ctx, _ := f(v)
+	if len(ctx) != 1 || ctx[0].Name != "$[0].ID" {
+		t.Errorf("\nUnexpected ctx: %+v", ctx)
+	}
+}
+
+type account struct {
+	ID      int
+	Name    string
+	Age     int
+	Tags    []string
+	Friends []account
+	cache   map[string]int
+}
+
+func TestMatchesFields(t *testing.T) {
+	var v = account{ID: 123, Name: "alice", Age: 28, Tags: []string{"a"}}
+	var young = &predicate.Predicate{}
+	young.RegisterPredicate(impl.Lt(30))
+
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"Name": "alice",
+		"Age":  young,
+		"Tags": []string{"a"},
+	})), expectation{value: v, pass: true})
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"Name": "bob",
+	})), expectation{value: v, pass: false})
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"Age": young,
+	})), expectation{value: account{Age: 45}, pass: false})
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"name": "alice",
+	})), expectation{value: map[string]string{"name": "alice"}, pass: true})
+}
+
+func TestMatchesFieldsErrors(t *testing.T) {
+	var v = account{ID: 123, Name: "alice", Tags: []string{"a"}}
+	var prefixed = &predicate.Predicate{}
+	prefixed.RegisterPredicate(impl.StartsWith("x"))
+
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"Nmae": "alice",
+	})), expectation{
+		value:    v,
+		errorMsg: "no field 'Nmae' on type 'account'; did you mean 'Name'?",
+	})
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"ID": prefixed,
+	})), expectation{
+		value:    v,
+		errorMsg: "field $.ID cannot be evaluated: value of type 'int' is not a sequence",
+	})
+	verifyPredicate(t, pr(impl.MatchesFields(map[string]interface{}{
+		"[0]": prefixed,
+	})), expectation{
+		value:    []int{1},
+		errorMsg: "field $[0] cannot be evaluated: value of type 'int' is not a sequence",
+	})
+}
+
+func TestMatchesFieldsContext(t *testing.T) {
+	var young = &predicate.Predicate{}
+	young.RegisterPredicate(impl.Lt(30))
+
+	var desc, p = impl.MatchesFields(map[string]interface{}{
+		"Name": "bob",
+		"Age":  young,
+		"ID":   123,
+	})
+	if desc != `{} matches fields Age < 30, ID == 123, Name == "bob"` {
+		t.Errorf("\nunexpected description: %v", desc)
+	}
+	_, ctx, _ := p(account{ID: 123, Name: "alice", Age: 45})
+	if len(ctx) != 1 || ctx[0].Name != "mismatched fields" ||
+		ctx[0].Value != "$.Age: 45, expected Age < 30\n"+
+			`$.Name: "alice", expected Name == "bob"` {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+
+	var short = &predicate.Predicate{}
+	short.RegisterTransformation(impl.Length())
+	short.RegisterPredicate(impl.Lt(2))
+	_, p = impl.MatchesFields(map[string]interface{}{"Tags": short})
+	_, ctx, _ = p(account{Tags: []string{"a", "b"}})
+	if len(ctx) != 2 || ctx[0].Value != `$.Tags: []string{ "a", "b" }, expected length(Tags) < 2` ||
+		ctx[1].Name != "length" || ctx[1].Value != 2 {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
+
+func TestIsEqualIgnoring(t *testing.T) {
+	var v = account{ID: 1, Name: "alice", Friends: []account{{ID: 2, Name: "bob"}},
+		cache: map[string]int{"a": 1}}
+	var rhs = account{ID: 3, Name: "alice", Friends: []account{{ID: 4, Name: "bob"}}}
+
+	verifyPredicate(t, pr(impl.IsEqualIgnoring(rhs, "ID", "Friends.ID")), expectation{
+		value: v,
+		pass:  true,
+	})
+	verifyPredicate(t, pr(impl.IsEqualIgnoring(rhs, "ID")), expectation{
+		value: v,
+		pass:  false,
+	})
+}
+
+func TestIsEqualIgnoringContext(t *testing.T) {
+	var _, p = impl.IsEqualIgnoring(account{ID: 1, Name: "bob"}, "ID")
+	_, ctx, _ := p(account{ID: 2, Name: "alice"})
+	if len(ctx) != 1 || ctx[0].Name != "diff" ||
+		ctx[0].Value != `~ .Name: "bob" → "alice"` {
+		t.Errorf("\nunexpected context: %v", ctx)
+	}
+}
@@ -8,6 +8,7 @@ package prettyprint
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Formatter contains the configuration
@@ -43,8 +44,17 @@ func FormatValue(v interface{}) string {
 	return Default.FormatValue(v)
 }
 
-// FormatValue return the value formated according to the local settings
+// FormatValue return the value formated according to the local settings.
+// Durations and times are formatted in their human-readable form instead of
+// their literal go form.
 func (f *Formatter) FormatValue(v interface{}) string {
+	switch v := v.(type) {
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+
 	str := fmt.Sprintf("%#v", v)
 	tokenList := tokenize(str)
 	tokens := buildTokenTree(tokenList)
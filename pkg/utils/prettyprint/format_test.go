@@ -3,6 +3,7 @@ package prettyprint_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
 )
@@ -219,3 +220,20 @@ func TestFormatInterfaceArray(t *testing.T) {
 		t.Errorf("\nunexpected output: |%v|", s)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Test formatting of time values
+
+func TestFormatDuration(t *testing.T) {
+	s := prettyprint.FormatValue(1500 * time.Millisecond)
+	if s != `1.5s` {
+		t.Errorf("\nunexpected output: |%v|", s)
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	s := prettyprint.FormatValue(time.Date(2020, 1, 2, 3, 4, 5, 600, time.UTC))
+	if s != `2020-01-02T03:04:05.0000006Z` {
+		t.Errorf("\nunexpected output: |%v|", s)
+	}
+}
@@ -0,0 +1,303 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+)
+
+// DeepCopy returns a copy of a value that shares no mutable state with the
+// original: pointers, slices, maps and interfaces are recursively duplicated.
+// Aliasing and cycles through pointers and maps are preserved within the
+// copied value, but slices sharing a backing array are copied independently.
+// Unexported struct fields cannot be modified through reflection and are
+// copied shallowly, while functions and channels are shared between the
+// original and the copy.
+func DeepCopy[T any](v T) T {
+	var c = copier{pointers: map[copiedPointer]reflect.Value{}}
+	var r T
+	reflect.ValueOf(&r).Elem().Set(c.copy(reflect.ValueOf(&v).Elem()))
+	return r
+}
+
+// With returns a deep copy of `obj` where the value designated by `keypath` is
+// replaced by `v`, converted to the target type if needed. Nil pointers and
+// maps along the keypath are allocated, and missing map keys are added. See
+// `Field()` for more details about keypaths; indexes must be within the
+// bounds of their sequence, and wildcards and filters update all the matching
+// elements. Method calls cannot be used as targets.
+//
+//	var variant, err = value.With(base, "Order.Items[2].Qty", 5)
+func With[T any](obj T, keypath string, v interface{}) (T, error) {
+	segments, err := parseKeypath(keypath)
+	if err != nil {
+		return obj, err
+	}
+	var r = DeepCopy(obj)
+	if err := setField(reflect.ValueOf(&r).Elem(), segments, v); err != nil {
+		return obj, err
+	}
+	return r, nil
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for deep copies
+
+type copiedPointer struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type copier struct {
+	pointers map[copiedPointer]reflect.Value
+}
+
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		var key = copiedPointer{v.Pointer(), v.Type()}
+		if r, ok := c.pointers[key]; ok {
+			return r
+		}
+		r := reflect.New(v.Type().Elem())
+		c.pointers[key] = r
+		r.Elem().Set(c.copy(v.Elem()))
+		return r
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.New(v.Type()).Elem()
+		r.Set(c.copy(v.Elem()))
+		return r
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return r
+
+	case reflect.Array:
+		r := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return r
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		var key = copiedPointer{v.Pointer(), v.Type()}
+		if r, ok := c.pointers[key]; ok {
+			return r
+		}
+		r := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.pointers[key] = r
+		for it := v.MapRange(); it.Next(); {
+			r.SetMapIndex(it.Key(), c.copy(it.Value()))
+		}
+		return r
+
+	case reflect.Struct:
+		r := reflect.New(v.Type()).Elem()
+		r.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				r.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+		return r
+	}
+	return v
+}
+
+// Helper functions for deep copies
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for keypath updates
+
+// setField updates the value designated by a keypath within a settable value.
+func setField(target reflect.Value, keypath []keypathSegment, v interface{}) error {
+	if len(keypath) == 0 {
+		return assignValue(target, v)
+	}
+
+	var segment = keypath[0]
+	if segment.kind == nameSegment && segment.call {
+		return fmt.Errorf("cannot update the result of method '%v'", segment.name)
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return setField(target.Elem(), keypath, v)
+
+	case reflect.Interface:
+		if target.IsNil() {
+			return fmt.Errorf("cannot update '%v' on nil value of type '%v'",
+				formatSegment(segment), target.Type())
+		}
+		e := reflect.New(target.Elem().Type()).Elem()
+		e.Set(target.Elem())
+		if err := setField(e, keypath, v); err != nil {
+			return err
+		}
+		target.Set(e)
+		return nil
+
+	case reflect.Struct:
+		if segment.kind != nameSegment {
+			break
+		}
+		vt := target.Type()
+		field, ok := vt.FieldByName(segment.name)
+		if !ok {
+			return fmt.Errorf("no field '%v' on type '%v'%v", segment.name,
+				typeName(vt), didYouMean(segment.name, fieldNames(vt), "'"))
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("field '%v' on type '%v' is not exported",
+				segment.name, typeName(vt))
+		}
+		return setField(target.FieldByIndex(field.Index), keypath[1:], v)
+
+	case reflect.Slice, reflect.Array:
+		return setSequenceField(target, keypath, v)
+
+	case reflect.Map:
+		return setMapField(target, keypath, v)
+	}
+	return fmt.Errorf("cannot update '%v' on value of type '%v'",
+		formatSegment(segment), typeName(target.Type()))
+}
+
+func setSequenceField(target reflect.Value, keypath []keypathSegment, v interface{}) error {
+	var segment = keypath[0]
+	switch segment.kind {
+	case indexSegment:
+		i := segment.index
+		if i < 0 {
+			i += target.Len()
+		}
+		if i < 0 || i >= target.Len() {
+			return fmt.Errorf("index %v out of range for sequence of length %v",
+				segment.index, target.Len())
+		}
+		return setField(target.Index(i), keypath[1:], v)
+
+	case wildcardSegment, filterSegment:
+		for i := 0; i < target.Len(); i++ {
+			e := target.Index(i)
+			if segment.kind == filterSegment && !segment.filter.matchElement(e) {
+				continue
+			}
+			if err := setField(e, keypath[1:], v); err != nil {
+				return fmt.Errorf("at index %v, %w", i, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot update '%v' on value of type '%v'",
+		formatSegment(segment), typeName(target.Type()))
+}
+
+func setMapField(target reflect.Value, keypath []keypathSegment, v interface{}) error {
+	if target.IsNil() {
+		target.Set(reflect.MakeMap(target.Type()))
+	}
+
+	var segment = keypath[0]
+	switch segment.kind {
+	case wildcardSegment, filterSegment:
+		for _, k := range SortedKeys(target) {
+			e := target.MapIndex(k)
+			if segment.kind == filterSegment && !segment.filter.matchElement(e) {
+				continue
+			}
+			if err := setMapEntry(target, k, keypath[1:], v); err != nil {
+				return fmt.Errorf("at key %v, %w", prettyprint.FormatValue(k.Interface()), err)
+			}
+		}
+		return nil
+
+	case keySegment, indexSegment:
+		var key interface{} = segment.name
+		if segment.kind == indexSegment {
+			key = segment.index
+		}
+		k, ok := mapKey(target.Type(), key)
+		if !ok {
+			return fmt.Errorf("key %v is not valid for map of type '%v'",
+				prettyprint.FormatValue(key), typeName(target.Type()))
+		}
+		return setMapEntry(target, k, keypath[1:], v)
+	}
+
+	// As with Field(), partial keypaths made of consecutive plain names are
+	// considered as potential keys, falling back to the first name alone when
+	// none of them exist yet.
+	var names []string
+	for _, s := range keypath {
+		if s.kind != nameSegment || s.call {
+			break
+		}
+		names = append(names, s.name)
+		if k, ok := mapKey(target.Type(), strings.Join(names, ".")); ok {
+			if target.MapIndex(k).IsValid() {
+				return setMapEntry(target, k, keypath[len(names):], v)
+			}
+		}
+	}
+	k, ok := mapKey(target.Type(), segment.name)
+	if !ok {
+		return fmt.Errorf("key %v is not valid for map of type '%v'",
+			prettyprint.FormatValue(segment.name), typeName(target.Type()))
+	}
+	return setMapEntry(target, k, keypath[1:], v)
+}
+
+// setMapEntry updates a copy of the map entry for key `k`, and stores it back
+// into the map, as map entries are not addressable.
+func setMapEntry(target, k reflect.Value, keypath []keypathSegment, v interface{}) error {
+	e := reflect.New(target.Type().Elem()).Elem()
+	if existing := target.MapIndex(k); existing.IsValid() {
+		e.Set(existing)
+	}
+	if err := setField(e, keypath, v); err != nil {
+		return err
+	}
+	target.SetMapIndex(k, e)
+	return nil
+}
+
+// assignValue sets the target to a new value, converted to the target type if
+// they are of compatible kinds.
+func assignValue(target reflect.Value, v interface{}) error {
+	rv, ok := convertArgument(v, target.Type())
+	if !ok {
+		if v == nil {
+			return fmt.Errorf("nil cannot be assigned to value of type '%v'", target.Type())
+		}
+		return fmt.Errorf("value of type '%T' cannot be assigned to value of type '%v'",
+			v, target.Type())
+	}
+	target.Set(rv)
+	return nil
+}
+
+// Helper functions for keypath updates
+// ---------------------------------------------------------------------------
@@ -0,0 +1,193 @@
+package value_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+type item struct {
+	SKU string
+	Qty int
+}
+
+type order struct {
+	ID       int
+	Items    []item
+	Customer *User
+	Tags     map[string]string
+	Extra    interface{}
+	notes    []string
+}
+
+type invoice struct {
+	Order  order
+	Ref    *order
+	Totals [2]float64
+}
+
+func TestDeepCopy(t *testing.T) {
+	var base = invoice{
+		Order: order{
+			ID:       1,
+			Items:    []item{{"a", 1}, {"b", 2}},
+			Customer: &User{Name: "alice"},
+			Tags:     map[string]string{"k": "v"},
+			Extra:    []int{1, 2},
+			notes:    []string{"n"},
+		},
+		Totals: [2]float64{1, 2},
+	}
+	var c = value.DeepCopy(base)
+	if !reflect.DeepEqual(c, base) {
+		t.Fatalf("\nDeepCopy() returned a different value\nexpected: %+v\nactual:   %+v",
+			base, c)
+	}
+
+	c.Order.Items[0].Qty = 10
+	c.Order.Customer.Name = "bob"
+	c.Order.Tags["k"] = "w"
+	c.Order.Extra.([]int)[0] = 10
+	if base.Order.Items[0].Qty != 1 || base.Order.Customer.Name != "alice" ||
+		base.Order.Tags["k"] != "v" || base.Order.Extra.([]int)[0] != 1 {
+		t.Errorf("\nDeepCopy() result is aliasing the original value: %+v", base)
+	}
+}
+
+func TestDeepCopyPreservesAliasingAndCycles(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	var a = &node{Name: "a"}
+	var b = &node{Name: "b", Next: a}
+	a.Next = b
+
+	var c = value.DeepCopy(a)
+	if c == a || c.Next == b || c.Next.Next != c {
+		t.Errorf("\nDeepCopy() did not preserve the structure of a cycle")
+	}
+
+	var o = &order{ID: 1}
+	var i = value.DeepCopy([]*order{o, o})
+	if i[0] != i[1] || i[0] == o {
+		t.Errorf("\nDeepCopy() did not preserve aliasing between pointers")
+	}
+
+	var m = map[string]interface{}{}
+	m["self"] = m
+	var mc = value.DeepCopy([]map[string]interface{}{m, m})
+	mc[0]["k"] = 1
+	if mc[1]["k"] != 1 || mc[0]["self"].(map[string]interface{})["k"] != 1 || m["k"] != nil {
+		t.Errorf("\nDeepCopy() did not preserve aliasing between maps")
+	}
+}
+
+func TestDeepCopyOfNilValues(t *testing.T) {
+	var err error
+	if value.DeepCopy(err) != nil {
+		t.Errorf("\nDeepCopy() of a nil interface is not nil")
+	}
+	var m map[string]int
+	if value.DeepCopy(m) != nil {
+		t.Errorf("\nDeepCopy() of a nil map is not nil")
+	}
+}
+
+func TestWith(t *testing.T) {
+	var base = invoice{
+		Order: order{
+			Items: []item{{"a", 1}, {"b", 2}, {"c", 3}},
+		},
+	}
+
+	r, err := value.With(base, "Order.Items[2].Qty", 5)
+	if err != nil {
+		t.Fatalf("\nWith() failed: %v", err)
+	}
+	if r.Order.Items[2].Qty != 5 || base.Order.Items[2].Qty != 3 {
+		t.Errorf("\nWith() returned unexpected value: %+v", r)
+	}
+
+	r, err = value.With(base, "Order.Customer.Address.City", "Paris")
+	if err != nil || r.Order.Customer == nil || r.Order.Customer.Address.City != "Paris" {
+		t.Errorf("\nWith() failed to allocate nil pointer: %+v, %v", r, err)
+	}
+	if base.Order.Customer != nil {
+		t.Errorf("\nWith() modified the original value")
+	}
+
+	r, err = value.With(base, `Order.Tags["key.with.dots"]`, "v")
+	if err != nil || r.Order.Tags["key.with.dots"] != "v" || base.Order.Tags != nil {
+		t.Errorf("\nWith() failed to allocate nil map: %+v, %v", r, err)
+	}
+
+	r, err = value.With(base, "Order.Items[*].Qty", int64(0))
+	if err != nil || !reflect.DeepEqual(r.Order.Items, []item{{"a", 0}, {"b", 0}, {"c", 0}}) {
+		t.Errorf("\nWith() failed to update all elements: %+v, %v", r, err)
+	}
+
+	r, err = value.With(base, `Order.Items[?(SKU=="b")].Qty`, 20)
+	if err != nil || !reflect.DeepEqual(r.Order.Items, []item{{"a", 1}, {"b", 20}, {"c", 3}}) {
+		t.Errorf("\nWith() failed to update filtered elements: %+v, %v", r, err)
+	}
+
+	r, err = value.With(base, "Totals[-1]", 2.5)
+	if err != nil || r.Totals[1] != 2.5 {
+		t.Errorf("\nWith() failed to update array element: %+v, %v", r, err)
+	}
+}
+
+func TestWithOnMaps(t *testing.T) {
+	var base = obj{
+		"Name":  "alice",
+		"Items": []interface{}{obj{"Qty": 1}},
+	}
+
+	r, err := value.With(base, "Items[0].Qty", 2)
+	if err != nil || value.Field(r, "Items[0].Qty") != 2 {
+		t.Errorf("\nWith() returned unexpected value: %+v, %v", r, err)
+	}
+	if value.Field(base, "Items[0].Qty") != 1 {
+		t.Errorf("\nWith() modified the original value")
+	}
+
+	r, err = value.With(base, "Address", obj{"City": "Paris"})
+	if err != nil || value.Field(r, "Address.City") != "Paris" {
+		t.Errorf("\nWith() failed to add key: %+v, %v", r, err)
+	}
+}
+
+func TestWithErrors(t *testing.T) {
+	var base = invoice{Order: order{Items: []item{{"a", 1}}}}
+	var inputs = []struct {
+		keypath string
+		value   interface{}
+		err     string
+	}{
+		{"Order.Itmes[0].Qty", 1, "no field 'Itmes' on type 'order'; did you mean 'Items'?"},
+		{"Order.Items[1].Qty", 1, "index 1 out of range for sequence of length 1"},
+		{"Order.Items[0].Qty", "1", "value of type 'string' cannot be assigned to value of type 'int'"},
+		{"Order.Items[0].Qty", nil, "nil cannot be assigned to value of type 'int'"},
+		{"Order.notes", nil, "field 'notes' on type 'order' is not exported"},
+		{"Order.Extra.Name", 1, "cannot update 'Name' on nil value of type 'interface {}'"},
+		{"Order.Customer.Greet(\"hi\", 1)", 1, "cannot update the result of method 'Greet'"},
+		{"Order.ID.Value", 1, "cannot update 'Value' on value of type 'int'"},
+		{"Order.Items[", 1, "invalid keypath 'Order.Items[': unterminated '['"},
+	}
+
+	for _, input := range inputs {
+		r, err := value.With(base, input.keypath, input.value)
+		if err == nil {
+			t.Errorf("\nexpected error for With(..., %q)", input.keypath)
+		} else if err.Error() != input.err {
+			t.Errorf("\nunexpected error for With(..., %q):\nexpected: %v\nactual:   %v",
+				input.keypath, input.err, err)
+		}
+		if !reflect.DeepEqual(r, base) {
+			t.Errorf("\nWith(..., %q) did not return the original value on error",
+				input.keypath)
+		}
+	}
+}
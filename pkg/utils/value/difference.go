@@ -0,0 +1,414 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+)
+
+// DifferenceMetric identifies how the difference between two numeric values is
+// measured by `MaxDifference()`.
+type DifferenceMetric int
+
+// Supported difference metrics
+const (
+	// AbsoluteDifference measures the difference as `|a - b|`, or as the
+	// modulus of the difference for complex numbers.
+	AbsoluteDifference DifferenceMetric = iota
+
+	// RelativeDifference measures the difference as `|a - b| / max(|a|, |b|)`,
+	// and is 0 when both values are 0.
+	RelativeDifference
+
+	// ULPDifference measures the difference as the number of representable
+	// floating point values between `a` and `b`, in single precision if both
+	// values are float32 or complex64, and in double precision otherwise.
+	// Complex numbers use the largest difference of their components.
+	ULPDifference
+)
+
+// MaxDifference returns the maximum difference of all the numeric components of
+// two values according to the specified metric, along with the keypath of the
+// components where it was found, e.g. `.Points[3].X`, or an empty keypath for
+// scalar values. Both values must have the same shape: sequences must have the
+// same length, maps the same keys and structs the same type. Exported struct
+// fields are compared one by one and map values are matched by key. Leaf values
+// convertible to float64 or complex128 are compared numerically, and integers
+// too large to be converted exactly are compared by exact subtraction. Structs
+// without exported fields, like `time.Time`, have either no difference if equal
+// according to `CompareUnordered()`, or an infinite difference otherwise. Any
+// other leaf value fails with an error.
+//
+// NaN values are considered equal to each other, but infinitely different from
+// any other value. Similarly, equal infinities have no difference, but are
+// infinitely different from any other value.
+func MaxDifference(lhs, rhs interface{}, metric DifferenceMetric) (float64, string, error) {
+	var w = differenceWalker{metric: metric}
+	err := w.walk("", reflect.ValueOf(lhs), reflect.ValueOf(rhs))
+	if err != nil {
+		return 0, "", err
+	}
+	return w.max, w.keypath, nil
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for numeric differences
+
+type differenceWalker struct {
+	metric  DifferenceMetric
+	max     float64
+	keypath string
+	found   bool
+}
+
+func (w *differenceWalker) walk(path string, a, b reflect.Value) error {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() {
+			return fmt.Errorf("value of type '%v' cannot be compared to nil", a.Type())
+		}
+		if b.IsValid() {
+			return fmt.Errorf("nil cannot be compared to value of type '%v'", b.Type())
+		}
+		return nil
+	}
+
+	ka, kb := a.Kind(), b.Kind()
+	switch {
+	case isSequenceKind(ka) && isSequenceKind(kb):
+		na, nb := a.Len(), b.Len()
+		if na != nb {
+			return fmt.Errorf("value length (%v and %v) mismatched", na, nb)
+		}
+		for i := 0; i < na; i++ {
+			err := w.walk(fmt.Sprintf("%v[%v]", path, i), a.Index(i), b.Index(i))
+			if err != nil {
+				return fmt.Errorf("failed to compare values at index %v, %v", i, err)
+			}
+		}
+		return nil
+
+	case ka == reflect.Struct && kb == reflect.Struct && hasExportedFields(a.Type()):
+		if a.Type() != b.Type() {
+			return fmt.Errorf("values of type '%v' and '%v' cannot be compared",
+				a.Type(), b.Type())
+		}
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			name := t.Field(i).Name
+			err := w.walk(path+"."+name, a.Field(i), b.Field(i))
+			if err != nil {
+				return fmt.Errorf("failed to compare values at field %v, %v", name, err)
+			}
+		}
+		return nil
+
+	case ka == reflect.Map || kb == reflect.Map:
+		if ka != kb || a.Type().Key() != b.Type().Key() {
+			return fmt.Errorf("values of type '%v' and '%v' cannot be compared",
+				a.Type(), b.Type())
+		}
+		if a.Len() != b.Len() {
+			return fmt.Errorf("map length (%v and %v) mismatched", a.Len(), b.Len())
+		}
+		for _, k := range SortedKeys(a) {
+			var key = prettyprint.FormatValue(k.Interface())
+			var e = b.MapIndex(k)
+			if !e.IsValid() {
+				return fmt.Errorf("key %v is missing from one of the values", key)
+			}
+			err := w.walk(AppendKeypath(path, k), a.MapIndex(k), e)
+			if err != nil {
+				return fmt.Errorf("failed to compare values at key %v, %v", key, err)
+			}
+		}
+		return nil
+	}
+
+	return w.compareLeaves(path, a, b)
+}
+
+func (w *differenceWalker) compareLeaves(path string, a, b reflect.Value) error {
+	var single = isSinglePrecision(a) && isSinglePrecision(b)
+	if isComplexKind(a.Kind()) || isComplexKind(b.Kind()) {
+		ca, ok := complexValue(a)
+		if !ok {
+			return notConvertibleError(a)
+		}
+		cb, ok := complexValue(b)
+		if !ok {
+			return notConvertibleError(b)
+		}
+		w.record(path, w.metric.complexDifference(ca, cb, single))
+		return nil
+	}
+
+	fa, oka := floatValue(a)
+	fb, okb := floatValue(b)
+	if oka && okb {
+		w.record(path, w.metric.floatDifference(fa, fb, single))
+		return nil
+	}
+
+	// Integers too large to be converted exactly to float64 are compared with
+	// an exact integer subtraction instead.
+	if isIntegerKind(a.Kind()) && isIntegerKind(b.Kind()) {
+		d, err := w.metric.integerDifference(a, b)
+		if err != nil {
+			return err
+		}
+		w.record(path, d)
+		return nil
+	}
+	if isNumericKind(a.Kind()) || isNumericKind(b.Kind()) {
+		if !oka {
+			return notConvertibleError(a)
+		}
+		return notConvertibleError(b)
+	}
+
+	// Opaque structs without exported fields, like time.Time, have no
+	// difference when equal, and an infinite difference otherwise.
+	if a.Kind() == reflect.Struct && b.Kind() == reflect.Struct &&
+		a.CanInterface() && b.CanInterface() {
+		if eq, err := CompareUnordered(a.Interface(), b.Interface()); err == nil {
+			if eq {
+				w.record(path, 0)
+			} else {
+				w.record(path, math.Inf(1))
+			}
+			return nil
+		}
+	}
+	if !oka {
+		return notConvertibleError(a)
+	}
+	return notConvertibleError(b)
+}
+
+func (w *differenceWalker) record(path string, d float64) {
+	if !w.found || d > w.max {
+		w.max, w.keypath, w.found = d, path, true
+	}
+}
+
+func (m DifferenceMetric) floatDifference(a, b float64, single bool) float64 {
+	if d, ok := specialDifference(a, b); ok {
+		return d
+	}
+	switch m {
+	case RelativeDifference:
+		return math.Abs(a-b) / math.Max(math.Abs(a), math.Abs(b))
+	case ULPDifference:
+		if single {
+			return float64(ulpDistance32(float32(a), float32(b)))
+		}
+		return float64(ulpDistance64(a, b))
+	}
+	return math.Abs(a - b)
+}
+
+func (m DifferenceMetric) complexDifference(a, b complex128, single bool) float64 {
+	if m == ULPDifference {
+		return math.Max(
+			m.floatDifference(real(a), real(b), single),
+			m.floatDifference(imag(a), imag(b), single))
+	}
+	switch {
+	case cmplx.IsNaN(a) || cmplx.IsNaN(b):
+		if cmplx.IsNaN(a) && cmplx.IsNaN(b) {
+			return 0
+		}
+		return math.Inf(1)
+	case a == b:
+		return 0
+	case cmplx.IsInf(a) || cmplx.IsInf(b):
+		return math.Inf(1)
+	case m == RelativeDifference:
+		return cmplx.Abs(a-b) / math.Max(cmplx.Abs(a), cmplx.Abs(b))
+	}
+	return cmplx.Abs(a - b)
+}
+
+// integerDifference returns the difference between two integer values, based
+// on the exact magnitude of their difference. The ULP metric is not defined
+// for integers that cannot be converted exactly to float64.
+func (m DifferenceMetric) integerDifference(a, b reflect.Value) (float64, error) {
+	if m == ULPDifference {
+		return 0, fmt.Errorf(
+			"values of type '%v' and '%v' cannot be converted to float without loss of precision",
+			a.Type(), b.Type())
+	}
+	d, ok := integerDistance(a, b)
+	if !ok {
+		return 0, fmt.Errorf(
+			"difference between values of type '%v' and '%v' overflows uint64",
+			a.Type(), b.Type())
+	}
+	if m == RelativeDifference {
+		return float64(d) / math.Max(integerMagnitude(a), integerMagnitude(b)), nil
+	}
+	return float64(d), nil
+}
+
+// integerDistance returns |a - b| for two values of integer kinds, or false
+// if the result does not fit in a uint64.
+func integerDistance(a, b reflect.Value) (uint64, bool) {
+	var signed = func(v reflect.Value) bool { return v.Kind() <= reflect.Int64 }
+	switch {
+	case signed(a) && signed(b):
+		x, y := a.Int(), b.Int()
+		if x < y {
+			x, y = y, x
+		}
+		return uint64(x) - uint64(y), true
+	case !signed(a) && !signed(b):
+		x, y := a.Uint(), b.Uint()
+		if x < y {
+			x, y = y, x
+		}
+		return x - y, true
+	case signed(b):
+		a, b = b, a
+	}
+	x, y := a.Int(), b.Uint()
+	if x >= 0 {
+		u := uint64(x)
+		if u > y {
+			return u - y, true
+		}
+		return y - u, true
+	}
+	var neg = uint64(-(x + 1)) + 1
+	if y > math.MaxUint64-neg {
+		return 0, false
+	}
+	return y + neg, true
+}
+
+func integerMagnitude(v reflect.Value) float64 {
+	if v.Kind() <= reflect.Int64 {
+		return math.Abs(float64(v.Int()))
+	}
+	return float64(v.Uint())
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uintptr
+}
+
+// specialDifference handles the comparison of equal values, NaNs and
+// infinities, which are common to all metrics.
+func specialDifference(a, b float64) (float64, bool) {
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		if math.IsNaN(a) && math.IsNaN(b) {
+			return 0, true
+		}
+		return math.Inf(1), true
+	case a == b:
+		return 0, true
+	case math.IsInf(a, 0) || math.IsInf(b, 0):
+		return math.Inf(1), true
+	}
+	return 0, false
+}
+
+// ulpDistance64 returns the number of representable float64 values between a
+// and b, by mapping their bit patterns onto a monotonic unsigned scale where
+// -0 and +0 coincide.
+func ulpDistance64(a, b float64) uint64 {
+	var ordered = func(x float64) uint64 {
+		bits := math.Float64bits(x)
+		if bits>>63 != 0 {
+			return ^bits + 1
+		}
+		return bits | 1<<63
+	}
+	x, y := ordered(a), ordered(b)
+	if x > y {
+		return x - y
+	}
+	return y - x
+}
+
+func ulpDistance32(a, b float32) uint32 {
+	var ordered = func(x float32) uint32 {
+		bits := math.Float32bits(x)
+		if bits>>31 != 0 {
+			return ^bits + 1
+		}
+		return bits | 1<<31
+	}
+	x, y := ordered(a), ordered(b)
+	if x > y {
+		return x - y
+	}
+	return y - x
+}
+
+// indirectValue dereferences pointers and interfaces, and returns an invalid
+// value for nil references.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// floatValue returns the value of any numeric kind as a float64, including
+// named numeric types, as long as the conversion is exact.
+func floatValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if x := v.Int(); x <= 1<<53 && x >= -(1<<53) {
+			return float64(x), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if x := v.Uint(); x <= 1<<53 {
+			return float64(x), true
+		}
+	}
+	return 0, false
+}
+
+func complexValue(v reflect.Value) (complex128, bool) {
+	if isComplexKind(v.Kind()) {
+		return v.Complex(), true
+	}
+	if x, ok := floatValue(v); ok {
+		return complex(x, 0), true
+	}
+	return 0, false
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func isSinglePrecision(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Complex64
+}
+
+func notConvertibleError(v reflect.Value) error {
+	return fmt.Errorf("value of type '%v' cannot be converted to float", v.Type())
+}
+
+// Helper functions for numeric differences
+// ---------------------------------------------------------------------------
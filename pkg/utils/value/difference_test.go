@@ -0,0 +1,153 @@
+package value_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+type point struct {
+	X, Y float64
+}
+
+type track struct {
+	Points []point
+	Start  time.Time
+}
+
+func TestMaxDifference(t *testing.T) {
+	var start = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var inputs = []struct {
+		lhs, rhs interface{}
+		metric   value.DifferenceMetric
+		diff     float64
+		keypath  string
+	}{
+		{1.0, 1.5, value.AbsoluteDifference, 0.5, ""},
+		{point{1, 2}, point{1.1, 2.3}, value.AbsoluteDifference, 0.3, ".Y"},
+		{&point{1, 2}, point{1.2, 2}, value.AbsoluteDifference, 0.2, ".X"},
+		{
+			map[string]float64{"a": 1, "b": 2},
+			map[string]float64{"a": 1.5, "b": 2.1},
+			value.AbsoluteDifference, 0.5, ".a",
+		},
+		{
+			track{Points: []point{{1, 1}, {2, 2}}, Start: start},
+			track{Points: []point{{1, 1}, {2, 2.4}}, Start: start},
+			value.AbsoluteDifference, 0.4, ".Points[1].Y",
+		},
+		{3 + 4i, 0i, value.AbsoluteDifference, 5, ""},
+		{[]complex64{1 + 1i}, []complex128{1 + 1.5i}, value.AbsoluteDifference, 0.5, "[0]"},
+
+		{100.0, 101.0, value.RelativeDifference, 1.0 / 101, ""},
+		{0.0, 0.0, value.RelativeDifference, 0, ""},
+		{[]float64{1, 10}, []float64{1.5, 11}, value.RelativeDifference, 0.5 / 1.5, "[0]"},
+
+		{1.0, math.Nextafter(1, 2), value.ULPDifference, 1, ""},
+		{-0.0, 0.0, value.ULPDifference, 0, ""},
+		{math.Nextafter(0, -1), math.Nextafter(0, 1), value.ULPDifference, 2, ""},
+		{float32(1), math.Nextafter32(1, 2), value.ULPDifference, 1, ""},
+
+		{math.NaN(), math.NaN(), value.AbsoluteDifference, 0, ""},
+		{math.Inf(1), math.Inf(1), value.RelativeDifference, 0, ""},
+		{[]float64{1, math.NaN()}, []float64{1, 2}, value.AbsoluteDifference, math.Inf(1), "[1]"},
+		{math.Inf(1), 1e300, value.ULPDifference, math.Inf(1), ""},
+		{
+			track{Points: []point{{1, 1}}, Start: start},
+			track{Points: []point{{1, 1}}, Start: start.Add(time.Second)},
+			value.AbsoluteDifference, math.Inf(1), ".Start",
+		},
+
+		{int64(1 << 60), int64(1<<60 + 1), value.AbsoluteDifference, 1, ""},
+		{time.Duration(1 << 60), time.Duration(1<<60 - 5), value.AbsoluteDifference, 5, ""},
+		{uint64(1 << 63), int64(-1 << 62), value.AbsoluteDifference, 3 << 62, ""},
+		{int64(-1 << 62), uint64(1<<63 + 4), value.AbsoluteDifference, 3<<62 + 4, ""},
+		{uint64(1 << 60), uint64(1<<60 + 4), value.RelativeDifference, 4.0 / (1<<60 + 4), ""},
+		{[]int64{1, 1 << 60}, []int64{1, 1<<60 + 2}, value.AbsoluteDifference, 2, "[1]"},
+	}
+
+	for _, input := range inputs {
+		d, keypath, err := value.MaxDifference(input.lhs, input.rhs, input.metric)
+		if err != nil {
+			t.Errorf("\nMaxDifference(%#v, %#v) failed: %v", input.lhs, input.rhs, err)
+			continue
+		}
+		if math.Abs(d-input.diff) > 1e-9 && d != input.diff {
+			t.Errorf("\nMaxDifference(%#v, %#v)\nexpected difference: %v\nactual: %v",
+				input.lhs, input.rhs, input.diff, d)
+		}
+		if keypath != input.keypath {
+			t.Errorf("\nMaxDifference(%#v, %#v)\nexpected keypath: %v\nactual: %v",
+				input.lhs, input.rhs, input.keypath, keypath)
+		}
+	}
+}
+
+func TestMaxDifferenceErrors(t *testing.T) {
+	var inputs = []struct {
+		lhs, rhs interface{}
+		err      string
+	}{
+		{
+			map[string]float64{"a": 1},
+			map[string]float64{"b": 1},
+			`key "a" is missing from one of the values`,
+		},
+		{
+			map[string]float64{"a": 1},
+			map[string]float64{"a": 1, "b": 2},
+			"map length (1 and 2) mismatched",
+		},
+		{
+			point{1, 2},
+			struct{ X, Y float64 }{1, 2},
+			"values of type 'value_test.point' and 'struct { X float64; Y float64 }' cannot be compared",
+		},
+		{
+			[]*point{{1, 2}},
+			[]*point{nil},
+			"failed to compare values at index 0, value of type 'value_test.point' cannot be compared to nil",
+		},
+		{
+			map[string]interface{}{"a": []float64{1}},
+			map[string]interface{}{"a": []float64{1, 2}},
+			`failed to compare values at key "a", value length (1 and 2) mismatched`,
+		},
+		{
+			"a", "a",
+			"value of type 'string' cannot be converted to float",
+		},
+		{
+			struct{ Label string }{"a"},
+			struct{ Label string }{"b"},
+			"failed to compare values at field Label, value of type 'string' cannot be converted to float",
+		},
+		{
+			int64(1 << 60), 1.0,
+			"value of type 'int64' cannot be converted to float",
+		},
+		{
+			uint64(math.MaxUint64), int64(-1),
+			"difference between values of type 'uint64' and 'int64' overflows uint64",
+		},
+	}
+
+	for _, input := range inputs {
+		_, _, err := value.MaxDifference(input.lhs, input.rhs, value.AbsoluteDifference)
+		if err == nil {
+			t.Errorf("\nexpected error for MaxDifference(%#v, %#v)", input.lhs, input.rhs)
+		} else if err.Error() != input.err {
+			t.Errorf("\nunexpected error for MaxDifference(...):\n%v", err)
+		}
+	}
+}
+
+func TestMaxDifferenceULPOfLargeIntegers(t *testing.T) {
+	_, _, err := value.MaxDifference(int64(1<<60), int64(1<<60+1), value.ULPDifference)
+	var expected = "values of type 'int64' and 'int64' cannot be converted to float without loss of precision"
+	if err == nil || err.Error() != expected {
+		t.Errorf("\nunexpected error: %v", err)
+	}
+}
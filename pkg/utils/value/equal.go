@@ -0,0 +1,325 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"sync"
+)
+
+// EqualOption customizes the behavior of `DeepEqual()`.
+type EqualOption func(*equalOptions)
+
+// EquateEmpty is an option that considers nil and empty slices or maps as
+// equal.
+func EquateEmpty() EqualOption {
+	return func(o *equalOptions) {
+		o.equateEmpty = true
+	}
+}
+
+// IgnoreUnexported is an option that skips unexported struct fields during
+// comparison.
+func IgnoreUnexported() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreUnexported = true
+	}
+}
+
+// EquateApprox is an option that considers floating point and complex values
+// as equal when the absolute value of their difference does not exceed
+// `tolerance`.
+func EquateApprox(tolerance float64) EqualOption {
+	return func(o *equalOptions) {
+		o.tolerance = tolerance
+	}
+}
+
+// Comparer is an option that compares all values of type T with the given
+// function, taking precedence over registered comparers and `Equal()`
+// methods.
+func Comparer[T any](f func(a, b T) bool) EqualOption {
+	t, c := makeComparer(f)
+	return func(o *equalOptions) {
+		if o.comparers == nil {
+			o.comparers = map[reflect.Type]comparer{}
+		}
+		o.comparers[t] = c
+	}
+}
+
+// RegisterComparer registers a function used by `DeepEqual()` to compare all
+// values of type T, unless overridden by a `Comparer()` option. Registered
+// comparers take precedence over `Equal()` methods. Registering a new comparer
+// for the same type replaces the previous one.
+func RegisterComparer[T any](f func(a, b T) bool) {
+	t, c := makeComparer(f)
+	registry.Lock()
+	defer registry.Unlock()
+	registry.comparers[t] = c
+}
+
+// DeepEqual compares two values recursively, according to the given options.
+// Unlike `reflect.DeepEqual()`, it honors comparers and `Equal(T) bool`
+// methods at every level, compares numeric values of different types by value
+// as `CompareUnordered()` does, and compares sequences of different types
+// element by element. Cyclic values are handled safely. Values of different
+// types that cannot be compared return an error.
+func DeepEqual(lhs, rhs interface{}, opts ...EqualOption) (bool, error) {
+	a, b := reflect.ValueOf(lhs), reflect.ValueOf(rhs)
+	if a.IsValid() && b.IsValid() && a.Type() != b.Type() && !mixedComparable(a, b) {
+		return false, fmt.Errorf(
+			"values of type '%T' and '%T' are never equal", lhs, rhs)
+	}
+
+	var s = equalState{visited: map[equalVisit]bool{}}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s.equal(a, b), nil
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for deep equality
+
+type comparer func(a, b reflect.Value) bool
+
+type equalOptions struct {
+	equateEmpty      bool
+	ignoreUnexported bool
+	tolerance        float64
+	comparers        map[reflect.Type]comparer
+}
+
+var registry = struct {
+	sync.RWMutex
+	comparers map[reflect.Type]comparer
+}{
+	comparers: map[reflect.Type]comparer{},
+}
+
+func makeComparer[T any](f func(a, b T) bool) (reflect.Type, comparer) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return t, func(a, b reflect.Value) bool {
+		return f(a.Interface().(T), b.Interface().(T))
+	}
+}
+
+func registeredComparer(t reflect.Type) (comparer, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	c, ok := registry.comparers[t]
+	return c, ok
+}
+
+type equalVisit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type equalState struct {
+	options equalOptions
+	visited map[equalVisit]bool
+}
+
+func (s *equalState) equal(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return s.equalMixed(a, b)
+	}
+	if eq, ok := s.equalCustom(a, b); ok {
+		return eq
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return s.equalFloat(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return s.equalComplex(a.Complex(), b.Complex())
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Pointer() == b.Pointer() || !s.enter(a, b) {
+			return true
+		}
+		return s.equal(a.Elem(), b.Elem())
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return s.equal(a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if s.options.ignoreUnexported && !t.Field(i).IsExported() {
+				continue
+			}
+			if !s.equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.Len() != b.Len() || (a.IsNil() != b.IsNil() && !s.options.equateEmpty) {
+			return false
+		}
+		if a.Pointer() == b.Pointer() || !s.enter(a, b) {
+			return true
+		}
+		for it := a.MapRange(); it.Next(); {
+			e := b.MapIndex(it.Key())
+			if !e.IsValid() || !s.equal(it.Value(), e) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice:
+		if a.Len() != b.Len() || (a.IsNil() != b.IsNil() && !s.options.equateEmpty) {
+			return false
+		}
+		if a.Pointer() == b.Pointer() || !s.enter(a, b) {
+			return true
+		}
+		return s.equalElements(a, b)
+
+	case reflect.Array:
+		return s.equalElements(a, b)
+	}
+	return false
+}
+
+// equalCustom compares two values of the same type with a comparer or an
+// `Equal()` method, if one is available.
+func (s *equalState) equalCustom(a, b reflect.Value) (eq bool, ok bool) {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+	t := a.Type()
+	c, ok := s.options.comparers[t]
+	if !ok {
+		c, ok = registeredComparer(t)
+	}
+	if ok {
+		return c(a, b), true
+	}
+
+	if a.Kind() == reflect.Interface || isNilPointer(a) || isNilPointer(b) {
+		return false, false
+	}
+	if m, arg, ok := findCompareMethod(a, b, "Equal", reflect.Bool); ok {
+		return m.Call([]reflect.Value{arg})[0].Bool(), true
+	}
+	return false, false
+}
+
+// equalMixed compares two values of different types, which can only be equal
+// if they are both numeric values or both sequences.
+func (s *equalState) equalMixed(a, b reflect.Value) bool {
+	if isSequenceKind(a.Kind()) && isSequenceKind(b.Kind()) {
+		return a.Len() == b.Len() && s.equalElements(a, b)
+	}
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+	lhs, rhs := a.Interface(), b.Interface()
+	if x, ok := AsInt(lhs); ok {
+		if y, ok := AsInt(rhs); ok {
+			return x == y
+		}
+	}
+	if x, ok := AsUInt(lhs); ok {
+		if y, ok := AsUInt(rhs); ok {
+			return x == y
+		}
+	}
+	if x, ok := AsFloat(lhs); ok {
+		if y, ok := AsFloat(rhs); ok {
+			return s.equalFloat(x, y)
+		}
+	}
+	if isComplexKind(a.Kind()) && isComplexKind(b.Kind()) {
+		return s.equalComplex(a.Complex(), b.Complex())
+	}
+	return false
+}
+
+func (s *equalState) equalElements(a, b reflect.Value) bool {
+	for i := 0; i < a.Len(); i++ {
+		if !s.equal(a.Index(i), b.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *equalState) equalFloat(a, b float64) bool {
+	if s.options.tolerance > 0 {
+		return math.Abs(a-b) <= s.options.tolerance
+	}
+	return a == b
+}
+
+func (s *equalState) equalComplex(a, b complex128) bool {
+	if s.options.tolerance > 0 {
+		return cmplx.Abs(a-b) <= s.options.tolerance
+	}
+	return a == b
+}
+
+// enter records a pair of references being visited, and returns false if the
+// pair was already visited, in which case the values are assumed to be equal
+// to prevent infinite recursion on cyclic values.
+func (s *equalState) enter(a, b reflect.Value) bool {
+	var k = equalVisit{a.Pointer(), b.Pointer(), a.Type()}
+	if s.visited[k] {
+		return false
+	}
+	s.visited[k] = true
+	return true
+}
+
+// mixedComparable returns true if values of two different types can be
+// compared for equality.
+func mixedComparable(a, b reflect.Value) bool {
+	ka, kb := a.Kind(), b.Kind()
+	return (isNumericKind(ka) && isNumericKind(kb)) ||
+		(isComplexKind(ka) && isComplexKind(kb)) ||
+		(isSequenceKind(ka) && isSequenceKind(kb))
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func isComplexKind(k reflect.Kind) bool {
+	return k == reflect.Complex64 || k == reflect.Complex128
+}
+
+func isSequenceKind(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array
+}
+
+// Helper functions for deep equality
+// ---------------------------------------------------------------------------
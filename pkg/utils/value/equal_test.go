@@ -0,0 +1,120 @@
+package value_test
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+type equalRecord struct {
+	Name  string
+	Tags  []string
+	Attrs map[string]int
+	Score float64
+	cache []int
+}
+
+type caseInsensitive string
+
+func (s caseInsensitive) Equal(other caseInsensitive) bool {
+	return strings.EqualFold(string(s), string(other))
+}
+
+type equalNode struct {
+	Value int
+	Next  *equalNode
+}
+
+func verifyDeepEqual(t *testing.T, lhs, rhs interface{}, expected bool, opts ...value.EqualOption) {
+	t.Helper()
+	eq, err := value.DeepEqual(lhs, rhs, opts...)
+	if err != nil {
+		t.Errorf("\nDeepEqual(%#v, %#v) failed: %v", lhs, rhs, err)
+	} else if eq != expected {
+		t.Errorf("\nDeepEqual(%#v, %#v) = %v, expected %v", lhs, rhs, eq, expected)
+	}
+}
+
+func TestDeepEqual(t *testing.T) {
+	verifyDeepEqual(t, 1, int64(1), true)
+	verifyDeepEqual(t, 1, 1.0, true)
+	verifyDeepEqual(t, []int{1, 2}, []int64{1, 2}, true)
+	verifyDeepEqual(t, []interface{}{1, "a"}, []interface{}{1.0, "a"}, true)
+	verifyDeepEqual(t, equalRecord{Name: "a"}, equalRecord{Name: "a"}, true)
+	verifyDeepEqual(t, equalRecord{Name: "a"}, equalRecord{Name: "b"}, false)
+	verifyDeepEqual(t, equalRecord{cache: []int{1}}, equalRecord{}, false)
+	verifyDeepEqual(t, 1+2i, complex64(1+2i), true)
+	verifyDeepEqual(t, nil, nil, true)
+	verifyDeepEqual(t, []int(nil), []int{}, false)
+
+	_, err := value.DeepEqual("1", 1)
+	if err == nil || err.Error() != "values of type 'string' and 'int' are never equal" {
+		t.Errorf("\nunexpected error: %v", err)
+	}
+}
+
+func TestDeepEqualOptions(t *testing.T) {
+	verifyDeepEqual(t, []int(nil), []int{}, true, value.EquateEmpty())
+	verifyDeepEqual(t,
+		equalRecord{Tags: []string{}, Attrs: map[string]int{}},
+		equalRecord{}, true, value.EquateEmpty())
+	verifyDeepEqual(t,
+		equalRecord{Name: "a", cache: []int{1}},
+		equalRecord{Name: "a"}, true, value.IgnoreUnexported())
+	verifyDeepEqual(t,
+		equalRecord{Score: 1.0001},
+		equalRecord{Score: 1.0}, true, value.EquateApprox(1e-3))
+	verifyDeepEqual(t,
+		map[string]interface{}{"a": []float64{1.0001}, "b": 1 + 1i},
+		map[string]interface{}{"a": []float64{1.0}, "b": 1.0001 + 1i},
+		true, value.EquateApprox(1e-3))
+	verifyDeepEqual(t,
+		equalRecord{Score: 1.01},
+		equalRecord{Score: 1.0}, false, value.EquateApprox(1e-3))
+}
+
+func TestDeepEqualWithEqualMethods(t *testing.T) {
+	var t1 = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var t2 = t1.In(time.FixedZone("CET", 3600))
+	verifyDeepEqual(t, t1, t2, true)
+	verifyDeepEqual(t, []time.Time{t1}, []time.Time{t2}, true)
+	verifyDeepEqual(t,
+		map[string]caseInsensitive{"a": "Hello"},
+		map[string]caseInsensitive{"a": "hello"}, true)
+	verifyDeepEqual(t,
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("10.0.0.1"), true)
+	verifyDeepEqual(t,
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("10.0.0.2"), false)
+}
+
+func TestDeepEqualWithComparers(t *testing.T) {
+	var byLength = value.Comparer(func(a, b string) bool {
+		return len(a) == len(b)
+	})
+	verifyDeepEqual(t, []string{"abc"}, []string{"xyz"}, true, byLength)
+	verifyDeepEqual(t, []string{"abc"}, []string{"xy"}, false, byLength)
+
+	type celsius float64
+	value.RegisterComparer(func(a, b celsius) bool {
+		return int(a) == int(b)
+	})
+	verifyDeepEqual(t, []celsius{20.1, 21.7}, []celsius{20.9, 21.2}, true)
+	verifyDeepEqual(t, []celsius{20.1}, []celsius{21.1}, false)
+	verifyDeepEqual(t, []celsius{20.1}, []celsius{20.9}, false,
+		value.Comparer(func(a, b celsius) bool { return a == b }))
+}
+
+func TestDeepEqualWithCyclicValues(t *testing.T) {
+	var a, b = &equalNode{Value: 1}, &equalNode{Value: 1}
+	a.Next, b.Next = a, b
+	verifyDeepEqual(t, a, b, true)
+
+	var c = &equalNode{Value: 1}
+	c.Next = &equalNode{Value: 2, Next: c}
+	verifyDeepEqual(t, a, c, false)
+}
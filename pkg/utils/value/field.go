@@ -1,14 +1,20 @@
 package value
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
 )
 
 // Field takes a value or an array of values, navigates through the data tree
 // according to a keypath, and returns the targeted values. `keypath` is a
 // dot-separated list of keys, each used as either field name in a struct, a key
-// in a map, or a niladic method name. Once a lookup is successful on the first
+// in a map, or a method name. Once a lookup is successful on the first
 // fragment of the keypath, the evaluation continue recursively with the lookup
 // result and the remainder of the keypath. If a key lookup fails, a nil value
 // is returned. If a key lookup results in a method invocation that yields
@@ -27,16 +33,303 @@ import (
 // is then a N-dimensional array, where N is the number of arrays traversed
 // along the path. Arrays are always returns as a type agnostic array
 // (`[]interface{}`), even if all the values have a consistent type.
+//
+// In addition to plain keys, a keypath fragment can be:
+//   - an index in brackets, e.g. `Users[0]`, counting from the end when
+//     negative, e.g. `Users[-1]`;
+//   - a wildcard, e.g. `Users[*].Name`, explicitly collecting the result of
+//     the sub-path on each element of a sequence or each value of a map,
+//     ordered by key;
+//   - a quoted key, e.g. `Headers["Content-Type"]` or `Attrs["key.with.dots"]`;
+//   - a filter, e.g. `Users[?(Active==true)]` or `Users[?(Age>=30)].Name`,
+//     retaining only the elements for which the comparison of a sub-keypath
+//     with a literal value succeeds, with `==`, `!=`, `<`, `<=`, `>` or `>=`;
+//   - a method call with literal arguments, e.g. `Format("2006-01-02")` or
+//     `Get(2)`.
+//
+// Invalid keypaths always yield a nil value; use `StrictField()` to get an
+// error instead.
 func Field(value interface{}, keypath string) interface{} {
-	var keys = strings.Split(keypath, ".")
-	var v = reflect.ValueOf(value)
-	var rv = field(v, keys)
+	segments, err := parseKeypath(keypath)
+	if err != nil {
+		return nil
+	}
+	var r = fieldResolver{}
+	rv, _ := r.field(reflect.ValueOf(value), segments)
 	if rv.IsValid() && rv.CanInterface() {
 		return rv.Interface()
 	}
 	return nil
 }
 
+// StrictField is similar to `Field()`, but returns an error describing the
+// first failed lookup instead of a nil value, e.g. a missing struct field, a
+// missing map key, an out-of-range index or a nil value along the path.
+// Errors on missing fields or keys suggest the nearest existing names.
+func StrictField(value interface{}, keypath string) (interface{}, error) {
+	segments, err := parseKeypath(keypath)
+	if err != nil {
+		return nil, err
+	}
+	var r = fieldResolver{strict: true}
+	rv, err := r.field(reflect.ValueOf(value), segments)
+	if err != nil {
+		return nil, err
+	}
+	if rv.IsValid() && rv.CanInterface() {
+		return rv.Interface(), nil
+	}
+	return nil, nil
+}
+
+// AppendKeypath appends a map key to a keypath, using the `.key` notation when
+// the key is a string that can be used as-is in a keypath, and the `[key]`
+// notation otherwise.
+func AppendKeypath(path string, k reflect.Value) string {
+	if k.Kind() == reflect.String && isKeypathIdentifier(k.String()) {
+		return path + "." + k.String()
+	}
+	return fmt.Sprintf("%v[%v]", path, prettyprint.FormatValue(k.Interface()))
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for keypath parsing
+
+type segmentKind int
+
+const (
+	nameSegment segmentKind = iota
+	indexSegment
+	wildcardSegment
+	keySegment
+	filterSegment
+)
+
+type keypathSegment struct {
+	kind   segmentKind
+	name   string        // field, key or method name, or quoted key
+	call   bool          // name is followed by a list of arguments
+	args   []interface{} // literal arguments of a method call
+	index  int
+	filter *keypathFilter
+}
+
+type keypathFilter struct {
+	keypath []keypathSegment
+	op      string
+	value   interface{}
+}
+
+// parseKeypath splits a keypath into a list of segments.
+func parseKeypath(keypath string) (segments []keypathSegment, err error) {
+	var s = keypath
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+
+		case '[':
+			end := closingIndex(s, '[', ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid keypath '%v': unterminated '['", keypath)
+			}
+			segment, err := parseBracketSegment(s[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid keypath '%v': %v", keypath, err)
+			}
+			segments = append(segments, segment)
+			s = s[end+1:]
+
+		default:
+			end := strings.IndexAny(s, ".[(")
+			if end < 0 {
+				end = len(s)
+			}
+			var segment = keypathSegment{kind: nameSegment, name: s[:end]}
+			s = s[end:]
+			if len(s) > 0 && s[0] == '(' {
+				end := closingIndex(s, '(', ')')
+				if end < 0 {
+					return nil, fmt.Errorf("invalid keypath '%v': unterminated '('", keypath)
+				}
+				segment.call = true
+				segment.args, err = parseArguments(s[1:end])
+				if err != nil {
+					return nil, fmt.Errorf("invalid keypath '%v': %v", keypath, err)
+				}
+				s = s[end+1:]
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return segments, nil
+}
+
+func parseBracketSegment(s string) (keypathSegment, error) {
+	switch {
+	case s == "*":
+		return keypathSegment{kind: wildcardSegment}, nil
+
+	case strings.HasPrefix(s, `"`):
+		key, err := strconv.Unquote(s)
+		if err != nil {
+			return keypathSegment{}, fmt.Errorf("invalid key '[%v]'", s)
+		}
+		return keypathSegment{kind: keySegment, name: key}, nil
+
+	case strings.HasPrefix(s, "?(") && strings.HasSuffix(s, ")"):
+		filter, err := parseFilter(s[2 : len(s)-1])
+		if err != nil {
+			return keypathSegment{}, err
+		}
+		return keypathSegment{kind: filterSegment, filter: filter}, nil
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return keypathSegment{}, fmt.Errorf("invalid index '[%v]'", s)
+	}
+	return keypathSegment{kind: indexSegment, index: index}, nil
+}
+
+var filterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseFilter(s string) (*keypathFilter, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			i = skipQuoted(s, i)
+			continue
+		}
+		for _, op := range filterOperators {
+			if !strings.HasPrefix(s[i:], op) {
+				continue
+			}
+			var lhs = strings.TrimPrefix(strings.TrimSpace(s[:i]), "@")
+			keypath, err := parseKeypath(lhs)
+			if err != nil {
+				return nil, err
+			}
+			v, err := parseLiteral(s[i+len(op):])
+			if err != nil {
+				return nil, err
+			}
+			return &keypathFilter{keypath: keypath, op: op, value: v}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid filter '%v'", s)
+}
+
+func parseArguments(s string) (args []interface{}, err error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var start = 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] == '"' {
+			i = skipQuoted(s, i)
+			continue
+		}
+		if i == len(s) || s[i] == ',' {
+			v, err := parseLiteral(s[start:i])
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, v)
+			start = i + 1
+		}
+	}
+	return args, nil
+}
+
+// parseLiteral parses a quoted string, a boolean, nil, an integer or a float
+// literal.
+func parseLiteral(s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "nil", "null":
+		return nil, nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if v, err := strconv.Unquote(s); err == nil {
+			return v, nil
+		}
+	} else if v, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return int(v), nil
+	} else if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v, nil
+	}
+	return nil, fmt.Errorf("invalid literal '%v'", s)
+}
+
+// closingIndex returns the index of the delimiter closing the one at the
+// beginning of `s`, skipping over nested delimiters and quoted strings, or -1
+// if there is none.
+func closingIndex(s string, open, close byte) int {
+	var depth = 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			i = skipQuoted(s, i)
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// skipQuoted returns the index of the closing quote of the quoted string
+// starting at index i.
+func skipQuoted(s string, i int) int {
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isKeypathIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper functions for keypath parsing
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Helper functions for keypath evaluation
+
+type fieldResolver struct {
+	strict bool
+}
+
+// fail returns an invalid value, along with an error in strict mode.
+func (r *fieldResolver) fail(format string, args ...interface{}) (reflect.Value, error) {
+	if r.strict {
+		return reflect.Value{}, fmt.Errorf(format, args...)
+	}
+	return reflect.Value{}, nil
+}
+
 func isNil(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr,
@@ -48,76 +341,411 @@ func isNil(v reflect.Value) bool {
 	}
 }
 
-func field(v reflect.Value, keypath []string) reflect.Value {
-	if len(keypath) == 0 || isNil(v) || !v.IsValid() {
-		return v
+func (r *fieldResolver) field(v reflect.Value, keypath []keypathSegment) (reflect.Value, error) {
+	if len(keypath) == 0 {
+		return v, nil
+	}
+	if !v.IsValid() || isNil(v) && v.Kind() != reflect.Slice && v.Kind() != reflect.Map {
+		if r.strict {
+			return r.fail("cannot evaluate '%v' on nil value%v",
+				formatSegment(keypath[0]), nilTypeSuffix(v))
+		}
+		return v, nil
+	}
+
+	var segment = keypath[0]
+	if segment.kind == nameSegment {
+		if rv, ok, err := r.method(v, segment); ok || err != nil {
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return r.field(rv, keypath[1:])
+		}
 	}
 
-	switch v.Type().Kind() {
+	switch v.Kind() {
 	case reflect.Ptr, reflect.Interface:
-		return field(v.Elem(), keypath)
+		return r.field(v.Elem(), keypath)
 
 	case reflect.Array, reflect.Slice:
-		var r = make([]interface{}, v.Len())
-		for i := 0; i < v.Len(); i++ {
-			var vv = v.Index(i)
-			var rv = field(vv, keypath)
-			if rv.IsValid() && rv.CanInterface() {
-				r[i] = rv.Interface()
-			}
-		}
-		return reflect.ValueOf(r)
+		return r.sequenceField(v, keypath)
 
 	case reflect.Struct:
-		var r = extractStructField(v, keypath[0])
-		return field(r, keypath[1:])
+		if segment.kind != nameSegment || segment.call {
+			return r.fail("cannot evaluate '%v' on value of type '%v'",
+				formatSegment(segment), typeName(v.Type()))
+		}
+		rv, err := r.structField(v, segment.name)
+		if err != nil || !rv.IsValid() {
+			return rv, err
+		}
+		return r.field(rv, keypath[1:])
 
 	case reflect.Map:
-		var r, remainingKeypath = extractMapField(v, keypath)
-		return field(r, remainingKeypath)
+		return r.mapField(v, keypath)
+	}
 
+	if segment.kind == nameSegment && !segment.call {
+		return r.fail("no field '%v' on type '%v'%v", segment.name,
+			typeName(v.Type()), didYouMean(segment.name, methodNames(v.Type()), "'"))
 	}
-	return reflect.Value{}
+	return r.fail("cannot evaluate '%v' on value of type '%v'",
+		formatSegment(segment), typeName(v.Type()))
 }
 
-func extractStructField(v reflect.Value, key string) reflect.Value {
+func (r *fieldResolver) sequenceField(v reflect.Value, keypath []keypathSegment) (reflect.Value, error) {
+	var segment = keypath[0]
+	switch segment.kind {
+	case indexSegment:
+		i := segment.index
+		if i < 0 {
+			i += v.Len()
+		}
+		if i < 0 || i >= v.Len() {
+			return r.fail("index %v out of range for sequence of length %v",
+				segment.index, v.Len())
+		}
+		return r.field(v.Index(i), keypath[1:])
+
+	case wildcardSegment:
+		return r.collect(v, keypath[1:])
+
+	case filterSegment:
+		return r.field(r.filter(v, segment.filter), keypath[1:])
+
+	case nameSegment:
+		return r.collect(v, keypath)
+	}
+	return r.fail("cannot evaluate '%v' on value of type '%v'",
+		formatSegment(segment), typeName(v.Type()))
+}
+
+// collect evaluates the keypath on each element of a sequence and returns the
+// results as an `[]interface{}`.
+func (r *fieldResolver) collect(v reflect.Value, keypath []keypathSegment) (reflect.Value, error) {
+	var result = make([]interface{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		rv, err := r.field(v.Index(i), keypath)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("at index %v, %w", i, err)
+		}
+		if rv.IsValid() && rv.CanInterface() {
+			result[i] = rv.Interface()
+		}
+	}
+	return reflect.ValueOf(result), nil
+}
+
+// filter returns the elements of a sequence for which the filter condition
+// holds, as an `[]interface{}`.
+func (r *fieldResolver) filter(v reflect.Value, filter *keypathFilter) reflect.Value {
+	var result = []interface{}{}
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i)
+		if e.CanInterface() && filter.matchElement(e) {
+			result = append(result, e.Interface())
+		}
+	}
+	return reflect.ValueOf(result)
+}
+
+// matchElement evaluates the filter keypath on an element and compares the
+// result with the filter value.
+func (f *keypathFilter) matchElement(e reflect.Value) bool {
+	var lenient = fieldResolver{}
+	rv, _ := lenient.field(e, f.keypath)
+	if !rv.IsValid() || !rv.CanInterface() {
+		return f.value == nil && (f.op == "==") != rv.IsValid()
+	}
+	return f.match(rv.Interface())
+}
+
+func (f *keypathFilter) match(v interface{}) bool {
+	switch f.op {
+	case "==", "!=":
+		eq, err := CompareUnordered(v, f.value)
+		if err != nil {
+			return f.op == "!="
+		}
+		return eq == (f.op == "==")
+	}
+	order, err := CompareOrdered(v, f.value)
+	if err != nil {
+		return false
+	}
+	switch f.op {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	}
+	return order >= 0
+}
+
+func (r *fieldResolver) structField(v reflect.Value, name string) (reflect.Value, error) {
 	vt := v.Type()
-	if field, ok := vt.FieldByName(key); ok {
+	if field, ok := vt.FieldByName(name); ok {
 		rv := v.FieldByIndex(field.Index)
 		if rv.IsValid() && rv.CanInterface() {
-			return rv
+			return rv, nil
 		}
+		return r.fail("field '%v' on type '%v' is not exported", name, typeName(vt))
 	}
-	if m, ok := vt.MethodByName(key); ok {
-		return invokeMethod(v, m)
+
+	return r.fail("no field '%v' on type '%v'%v", name, typeName(vt),
+		didYouMean(name, append(methodNames(vt), fieldNames(vt)...), "'"))
+}
+
+func (r *fieldResolver) mapField(v reflect.Value, keypath []keypathSegment) (reflect.Value, error) {
+	var segment = keypath[0]
+	switch segment.kind {
+	case wildcardSegment:
+		return r.collect(mapValues(v), keypath[1:])
+
+	case filterSegment:
+		return r.field(r.filter(mapValues(v), segment.filter), keypath[1:])
+
+	case keySegment, indexSegment:
+		var key interface{} = segment.name
+		if segment.kind == indexSegment {
+			key = segment.index
+		}
+		if rv, ok := mapIndex(v, key); ok {
+			return r.field(rv, keypath[1:])
+		}
+		return r.missingKey(v, key)
 	}
 
-	if v.CanAddr() {
-		var pv = v.Addr()
-		var pvt = pv.Type()
-		if m, ok := pvt.MethodByName(key); ok {
-			return invokeMethod(pv, m)
+	// Consider all partial keypaths made of consecutive plain names as
+	// potential keys.
+	var names []string
+	for _, s := range keypath {
+		if s.kind != nameSegment || s.call {
+			break
+		}
+		names = append(names, s.name)
+		if rv, ok := mapIndex(v, strings.Join(names, ".")); ok {
+			return r.field(rv, keypath[len(names):])
 		}
 	}
+	return r.missingKey(v, segment.name)
+}
 
-	return reflect.Value{}
+func (r *fieldResolver) missingKey(v reflect.Value, key interface{}) (reflect.Value, error) {
+	var candidates []string
+	if k, ok := key.(string); ok && v.Type().Key().Kind() == reflect.String {
+		for _, mk := range v.MapKeys() {
+			candidates = append(candidates, mk.String())
+		}
+		sort.Strings(candidates)
+		return r.fail("no key %v in map of type '%v'%v", prettyprint.FormatValue(key),
+			typeName(v.Type()), didYouMean(k, candidates, `"`))
+	}
+	return r.fail("no key %v in map of type '%v'", prettyprint.FormatValue(key),
+		typeName(v.Type()))
 }
 
-func extractMapField(v reflect.Value, keypath []string) (r reflect.Value, remainingKeypath []string) {
-	for i := range keypath {
-		var key = strings.Join(keypath[:i+1], ".")
-		var rv = v.MapIndex(reflect.ValueOf(key))
-		if rv.IsValid() && rv.CanInterface() {
-			return rv, keypath[i+1:]
+// method looks up and invokes a method matching a name segment. It returns
+// false if there is no such method, or if the segment is not an explicit call
+// and could designate a field or a key instead.
+func (r *fieldResolver) method(v reflect.Value, segment keypathSegment) (reflect.Value, bool, error) {
+	if !v.CanInterface() {
+		return reflect.Value{}, false, nil
+	}
+	m := v.MethodByName(segment.name)
+	if !m.IsValid() && v.CanAddr() {
+		m = v.Addr().MethodByName(segment.name)
+	}
+	if !m.IsValid() {
+		if segment.call && v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+			rv, err := r.fail("no method '%v' on type '%v'%v", segment.name,
+				typeName(v.Type()), didYouMean(segment.name, methodNames(v.Type()), "'"))
+			return rv, true, err
 		}
+		return reflect.Value{}, false, nil
 	}
-	return reflect.Value{}, nil
+	if !segment.call && v.Kind() == reflect.Struct {
+		if _, ok := v.Type().FieldByName(segment.name); ok {
+			return reflect.Value{}, false, nil
+		}
+	}
+	if !segment.call && v.Kind() == reflect.Map {
+		if _, ok := mapIndex(v, segment.name); ok {
+			return reflect.Value{}, false, nil
+		}
+	}
+
+	mt := m.Type()
+	if mt.NumOut() == 0 || mt.IsVariadic() || mt.NumIn() != len(segment.args) {
+		rv, err := r.fail("method '%v' on type '%v' cannot be called with %v argument(s)",
+			segment.name, typeName(v.Type()), len(segment.args))
+		return rv, true, err
+	}
+	var args = make([]reflect.Value, len(segment.args))
+	for i, arg := range segment.args {
+		av, ok := convertArgument(arg, mt.In(i))
+		if !ok {
+			rv, err := r.fail("argument %v of method '%v' on type '%v' should be of type '%v'",
+				prettyprint.FormatValue(arg), segment.name, typeName(v.Type()), mt.In(i))
+			return rv, true, err
+		}
+		args[i] = av
+	}
+
+	results := m.Call(args)
+	if last := results[len(results)-1]; len(results) > 1 && r.strict &&
+		last.Type() == errorType && !last.IsNil() {
+		return reflect.Value{}, true, fmt.Errorf("method '%v' on type '%v' failed: %w",
+			segment.name, typeName(v.Type()), last.Interface().(error))
+	}
+	return results[0], true, nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// convertArgument converts a literal argument into the type expected by a
+// method, as long as they are of compatible kinds.
+func convertArgument(arg interface{}, t reflect.Type) (reflect.Value, bool) {
+	if arg == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+			reflect.Ptr, reflect.Slice:
+			return reflect.Zero(t), true
+		}
+		return reflect.Value{}, false
+	}
+	av := reflect.ValueOf(arg)
+	if av.Type().AssignableTo(t) {
+		return av, true
+	}
+	var numeric = func(k reflect.Kind) bool {
+		return k >= reflect.Int && k <= reflect.Float64
+	}
+	if av.Kind() == t.Kind() || numeric(av.Kind()) && numeric(t.Kind()) {
+		if av.Type().ConvertibleTo(t) {
+			return av.Convert(t), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// mapIndex looks up a key in a map, converting the key to the map key type if
+// needed, including parsing numeric keys from their string representation.
+func mapIndex(v reflect.Value, key interface{}) (reflect.Value, bool) {
+	kv, ok := mapKey(v.Type(), key)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	rv := v.MapIndex(kv)
+	return rv, rv.IsValid() && rv.CanInterface()
+}
+
+// mapKey converts a key to the key type of a map type, returning false if the
+// key is not compatible.
+func mapKey(t reflect.Type, key interface{}) (reflect.Value, bool) {
+	keyType := t.Key()
+	kv := reflect.ValueOf(key)
+	if s, ok := key.(string); ok && keyType.Kind() != reflect.String &&
+		keyType.Kind() != reflect.Interface {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			kv = reflect.ValueOf(i)
+		} else {
+			return reflect.Value{}, false
+		}
+	}
+	if !kv.IsValid() {
+		return reflect.Value{}, false
+	}
+	if kv.Type().AssignableTo(keyType) {
+		return kv, true
+	}
+	if kv.Type().ConvertibleTo(keyType) &&
+		(kv.Kind() == reflect.String) == (keyType.Kind() == reflect.String) {
+		return kv.Convert(keyType), true
+	}
+	return reflect.Value{}, false
 }
 
-func invokeMethod(v reflect.Value, m reflect.Method) reflect.Value {
-	if m.Type.NumIn() == 1 && m.Type.NumOut() >= 1 {
-		rvs := m.Func.Call([]reflect.Value{v})
-		return rvs[0]
+// mapValues returns the values of a map ordered by key, as a reflected
+// `[]interface{}`.
+func mapValues(v reflect.Value) reflect.Value {
+	var values = make([]interface{}, 0, v.Len())
+	for _, kv := range collectMap(v) {
+		values = append(values, kv.Value)
 	}
-	return reflect.Value{}
+	return reflect.ValueOf(values)
 }
+
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			names = append(names, t.Field(i).Name)
+		}
+	}
+	return names
+}
+
+func methodNames(t reflect.Type) []string {
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		t = reflect.PointerTo(t)
+	}
+	var names []string
+	for i := 0; i < t.NumMethod(); i++ {
+		names = append(names, t.Method(i).Name)
+	}
+	return names
+}
+
+// didYouMean returns a suggestion listing the candidates nearest to name, or
+// an empty string if there are none.
+func didYouMean(name string, candidates []string, quote string) string {
+	var nearest = Nearest(name, candidates, 3)
+	if len(nearest) == 0 {
+		return ""
+	}
+	for i := range nearest {
+		nearest[i] = quote + nearest[i] + quote
+	}
+	var s = nearest[len(nearest)-1]
+	if len(nearest) > 1 {
+		s = strings.Join(nearest[:len(nearest)-1], ", ") + " or " + s
+	}
+	return "; did you mean " + s + "?"
+}
+
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+func nilTypeSuffix(v reflect.Value) string {
+	if v.IsValid() {
+		return fmt.Sprintf(" of type '%v'", v.Type())
+	}
+	return ""
+}
+
+func formatSegment(s keypathSegment) string {
+	switch s.kind {
+	case indexSegment:
+		return fmt.Sprintf("[%v]", s.index)
+	case wildcardSegment:
+		return "[*]"
+	case keySegment:
+		return fmt.Sprintf("[%q]", s.name)
+	case filterSegment:
+		return "[?(...)]"
+	}
+	if s.call {
+		return s.name + "(...)"
+	}
+	return s.name
+}
+
+// Helper functions for keypath evaluation
+// ---------------------------------------------------------------------------
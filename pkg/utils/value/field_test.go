@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/maargenton/go-testpredicate/pkg/utils/value"
@@ -202,6 +203,152 @@ func TestFieldWithNiladicFunctionTarget(t *testing.T) {
 	})
 }
 
+func TestFieldWithExtendedKeypaths(t *testing.T) {
+	var users = []User{
+		{Name: "alice", Age: 32, Active: true, Address: Address{City: "Paris"}},
+		{Name: "bob", Age: 25, Active: false, Address: Address{City: "Lyon"}},
+		{Name: "carol", Age: 41, Active: true, Address: Address{City: "Nice"}},
+	}
+	var v = obj{
+		"Users": users,
+		"Attrs": map[string]string{"key.with.dots": "value"},
+		"Codes": map[int]string{200: "OK", 404: "Not Found"},
+	}
+
+	verifyFieldTestCase(t, TestCase{
+		name:     "an index",
+		value:    v,
+		keypath:  "Users[1].Name",
+		expected: "bob",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a negative index",
+		value:    v,
+		keypath:  "Users[-1].Address.City",
+		expected: "Nice",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a leading index",
+		value:    users,
+		keypath:  "[0].Name",
+		expected: "alice",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "an out of range index",
+		value:    v,
+		keypath:  "Users[3].Name",
+		expected: nil,
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a wildcard on a sequence",
+		value:    v,
+		keypath:  "Users[*].Age",
+		expected: []interface{}{32, 25, 41},
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a wildcard on a map",
+		value:    v,
+		keypath:  "Codes[*]",
+		expected: []interface{}{"OK", "Not Found"},
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a quoted key",
+		value:    v,
+		keypath:  `Attrs["key.with.dots"]`,
+		expected: "value",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a numeric key",
+		value:    v,
+		keypath:  "Codes[404]",
+		expected: "Not Found",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "an equality filter",
+		value:    v,
+		keypath:  "Users[?(Active==true)].Name",
+		expected: []interface{}{"alice", "carol"},
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "an ordered filter",
+		value:    v,
+		keypath:  "Users[?(@.Age >= 30)].Address.City",
+		expected: []interface{}{"Paris", "Nice"},
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a filter with a string literal",
+		value:    v,
+		keypath:  `Users[?(Address.City != "Paris")][0].Name`,
+		expected: "bob",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a method with arguments",
+		value:    users,
+		keypath:  `[0].Greet("hello", 2)`,
+		expected: "hello alice, hello alice",
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "a method with mismatched arguments",
+		value:    users,
+		keypath:  `[0].Greet(2, "hello")`,
+		expected: nil,
+	})
+	verifyFieldTestCase(t, TestCase{
+		name:     "an invalid keypath",
+		value:    users,
+		keypath:  "[0",
+		expected: nil,
+	})
+}
+
+func TestStrictField(t *testing.T) {
+	var u = &User{Name: "alice", Address: Address{City: "Paris"}}
+	r, err := value.StrictField(u, "Address.City")
+	if err != nil || r != "Paris" {
+		t.Errorf("\nStrictField(u, \"Address.City\") returned %#v, %v", r, err)
+	}
+
+	r, err = value.StrictField(u, "Manager")
+	if err != nil || r != (*User)(nil) {
+		t.Errorf("\nStrictField(u, \"Manager\") returned %#v, %v", r, err)
+	}
+}
+
+func TestStrictFieldErrors(t *testing.T) {
+	var users = []User{{Name: "alice"}, {Name: "bob"}}
+	var inputs = []struct {
+		value   interface{}
+		keypath string
+		err     string
+	}{
+		{users[0], "Adress.City", "no field 'Adress' on type 'User'; did you mean 'Address'?"},
+		{users[0], "Address.Zip", "no field 'Zip' on type 'Address'"},
+		{users[0], "password", "field 'password' on type 'User' is not exported"},
+		{users[0], "Manager.Name", "cannot evaluate 'Name' on nil value of type '*value_test.User'"},
+		{users[0], "Name.Length", "no field 'Length' on type 'string'"},
+		{users, "[2]", "index 2 out of range for sequence of length 2"},
+		{users, "Nmae", "at index 0, no field 'Nmae' on type 'User'; did you mean 'Name'?"},
+		{obj{"Name": "alice"}, "Nme", `no key "Nme" in map of type 'obj'; did you mean "Name"?`},
+		{map[int]string{1: "a"}, "[2]", "no key 2 in map of type 'map[int]string'"},
+		{users[0], `Greet("hello")`, "method 'Greet' on type 'User' cannot be called with 1 argument(s)"},
+		{users[0], `Greet(1, 2)`, "argument 1 of method 'Greet' on type 'User' should be of type 'string'"},
+		{users[0], `Gret("hello", 1)`, "no method 'Gret' on type 'User'; did you mean 'Greet'?"},
+		{TestStruct{}, "FuncErr", "method 'FuncErr' on type 'TestStruct' failed: error"},
+		{users, "[?(Age)]", "invalid keypath '[?(Age)]': invalid filter 'Age'"},
+		{users, "[0", "invalid keypath '[0': unterminated '['"},
+	}
+
+	for _, input := range inputs {
+		_, err := value.StrictField(input.value, input.keypath)
+		if err == nil {
+			t.Errorf("\nexpected error for StrictField(..., %q)", input.keypath)
+		} else if err.Error() != input.err {
+			t.Errorf("\nunexpected error for StrictField(..., %q):\nexpected: %v\nactual:   %v",
+				input.keypath, input.err, err)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Support type
 
@@ -228,3 +375,45 @@ func (t TestStruct) FuncOther() (string, string) {
 func (t TestStruct) FuncArgs(i int) (string, error) {
 	return "FuncArgs", nil
 }
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Name     string
+	Age      int
+	Active   bool
+	Address  Address
+	Manager  *User
+	password string
+}
+
+func (u User) Greet(greeting string, n int) string {
+	var s []string
+	for i := 0; i < n; i++ {
+		s = append(s, greeting+" "+u.Name)
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestAppendKeypath(t *testing.T) {
+	var inputs = []struct {
+		key      interface{}
+		expected string
+	}{
+		{"name", "root.name"},
+		{"key-1_b", "root.key-1_b"},
+		{"key.with.dots", `root["key.with.dots"]`},
+		{"", `root[""]`},
+		{12, "root[12]"},
+	}
+
+	for _, input := range inputs {
+		r := value.AppendKeypath("root", reflect.ValueOf(input.key))
+		if r != input.expected {
+			t.Errorf("\nunexpected result from AppendKeypath(%#v)\nexpected: %v\nactual:   %v",
+				input.key, input.expected, r)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// KeyValue captures one entry of a map or one pair of values produced by an
+// `iter.Seq2`.
+type KeyValue struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// MaxCollectedElements is the maximum number of elements collected from an
+// iterator or a channel before giving up.
+var MaxCollectedElements = 10000
+
+// ChannelTimeout is the maximum time spent waiting for the next element while
+// draining a channel that has not been closed.
+var ChannelTimeout = 100 * time.Millisecond
+
+// IsIterator checks if a value is an `iter.Seq` or an `iter.Seq2`, or any
+// function with a compatible signature.
+func IsIterator(v interface{}) bool {
+	_, ok := iteratorArity(reflect.TypeOf(v))
+	return ok
+}
+
+// IsIterable checks if a value is an iterator, a map or a channel that can be
+// received from, and must be collected with `Collect()` before being treated
+// as a sequence.
+func IsIterable(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Chan:
+		return t.ChanDir()&reflect.RecvDir != 0
+	}
+	return IsIterator(v)
+}
+
+// SortedKeys returns the union of the keys of one or more maps of the same
+// type, ordered by value when they are comparable, and by their Go-syntax
+// representation otherwise.
+func SortedKeys(maps ...reflect.Value) []reflect.Value {
+	var keys []reflect.Value
+	for i, m := range maps {
+	next:
+		for _, k := range m.MapKeys() {
+			for _, prev := range maps[:i] {
+				if prev.MapIndex(k).IsValid() {
+					continue next
+				}
+			}
+			keys = append(keys, k)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return lessKey(keys[i].Interface(), keys[j].Interface())
+	})
+	return keys
+}
+
+// Collect materializes the elements of an iterable value into a slice. An
+// `iter.Seq[T]` and a channel of T produce a `[]T`, while an `iter.Seq2` and a
+// map produce a `[]KeyValue`, with map entries ordered by key. Channels are
+// drained until closed, or until no new element is received within
+// `ChannelTimeout`. Arrays, slices and strings are returned unchanged. Other
+// values, and iterables producing more than `MaxCollectedElements` elements,
+// return an error; the elements already received from a channel are returned
+// along with the error, as they cannot be received again.
+func Collect(v interface{}) (interface{}, error) {
+	vv := reflect.ValueOf(v)
+	if IsSequenceType(vv) {
+		return v, nil
+	}
+	if !IsIterable(v) {
+		return nil, fmt.Errorf("value of type '%T' is not a collection", v)
+	}
+
+	switch vv.Kind() {
+	case reflect.Map:
+		return collectMap(vv), nil
+	case reflect.Chan:
+		return collectChan(vv)
+	}
+	return collectIterator(vv)
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for collecting iterables
+
+// iteratorArity returns the number of values produced at each step by an
+// iterator type, i.e. a function accepting a single `yield` function returning
+// a bool.
+func iteratorArity(t reflect.Type) (int, bool) {
+	if t == nil || t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 0 {
+		return 0, false
+	}
+	yield := t.In(0)
+	if yield.Kind() != reflect.Func || yield.NumOut() != 1 ||
+		yield.Out(0).Kind() != reflect.Bool ||
+		yield.NumIn() < 1 || yield.NumIn() > 2 {
+		return 0, false
+	}
+	return yield.NumIn(), true
+}
+
+func collectIterator(v reflect.Value) (interface{}, error) {
+	if v.IsNil() {
+		return nil, fmt.Errorf("value of type '%v' is a nil iterator", v.Type())
+	}
+
+	arity, _ := iteratorArity(v.Type())
+	yieldType := v.Type().In(0)
+	overflow := false
+
+	if arity == 1 {
+		r := reflect.MakeSlice(reflect.SliceOf(yieldType.In(0)), 0, 0)
+		yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+			if r.Len() >= MaxCollectedElements {
+				overflow = true
+				return []reflect.Value{reflect.ValueOf(false)}
+			}
+			r = reflect.Append(r, args[0])
+			return []reflect.Value{reflect.ValueOf(true)}
+		})
+		v.Call([]reflect.Value{yield})
+		if overflow {
+			return nil, tooManyElementsError(v)
+		}
+		return r.Interface(), nil
+	}
+
+	var r = []KeyValue{}
+	yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+		if len(r) >= MaxCollectedElements {
+			overflow = true
+			return []reflect.Value{reflect.ValueOf(false)}
+		}
+		r = append(r, KeyValue{args[0].Interface(), args[1].Interface()})
+		return []reflect.Value{reflect.ValueOf(true)}
+	})
+	v.Call([]reflect.Value{yield})
+	if overflow {
+		return nil, tooManyElementsError(v)
+	}
+	return r, nil
+}
+
+func collectMap(v reflect.Value) []KeyValue {
+	var r = make([]KeyValue, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		r = append(r, KeyValue{iter.Key().Interface(), iter.Value().Interface()})
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		return lessKey(r[i].Key, r[j].Key)
+	})
+	return r
+}
+
+// lessKey orders map keys by value when they are comparable, and by their
+// Go-syntax representation otherwise.
+func lessKey(a, b interface{}) bool {
+	if order, err := CompareOrdered(a, b); err == nil {
+		return order < 0
+	}
+	return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+}
+
+func collectChan(v reflect.Value) (interface{}, error) {
+	r := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), 0, 0)
+	for {
+		timeout := reflect.ValueOf(time.After(ChannelTimeout))
+		chosen, e, ok := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: v},
+			{Dir: reflect.SelectRecv, Chan: timeout},
+		})
+		if chosen != 0 || !ok {
+			return r.Interface(), nil
+		}
+		r = reflect.Append(r, e)
+		if r.Len() > MaxCollectedElements {
+			return r.Interface(), tooManyElementsError(v)
+		}
+	}
+}
+
+func tooManyElementsError(v reflect.Value) error {
+	return fmt.Errorf("value of type '%v' produced more than %v elements",
+		v.Type(), MaxCollectedElements)
+}
+
+// Helper functions for collecting iterables
+// ---------------------------------------------------------------------------
@@ -0,0 +1,123 @@
+package value_test
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+func TestIsIterable(t *testing.T) {
+	var inputs = []struct {
+		value    interface{}
+		iterator bool
+		iterable bool
+	}{
+		{slices.Values([]int{1}), true, true},
+		{maps.All(map[string]int{}), true, true},
+		{func(yield func(int) bool) {}, true, true},
+		{map[string]int{}, false, true},
+		{make(chan int), false, true},
+		{make(<-chan int), false, true},
+		{make(chan<- int), false, false},
+		{[]int{}, false, false},
+		{func(int) bool { return true }, false, false},
+		{func(yield func(int, int, int) bool) {}, false, false},
+		{nil, false, false},
+	}
+	for _, input := range inputs {
+		if r := value.IsIterator(input.value); r != input.iterator {
+			t.Errorf("\nIsIterator(%T) = %v", input.value, r)
+		}
+		if r := value.IsIterable(input.value); r != input.iterable {
+			t.Errorf("\nIsIterable(%T) = %v", input.value, r)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	var ch = make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+
+	var open = make(chan int, 3)
+	open <- 1
+
+	var inputs = []struct {
+		value  interface{}
+		result interface{}
+	}{
+		{[]int{1, 2}, []int{1, 2}},
+		{"abc", "abc"},
+		{slices.Values([]int{1, 2, 3}), []int{1, 2, 3}},
+		{iter.Seq[int](func(yield func(int) bool) {}), []int{}},
+		{slices.All([]string{"a", "b"}), []value.KeyValue{{0, "a"}, {1, "b"}}},
+		{
+			map[string]int{"b": 2, "a": 1, "c": 3},
+			[]value.KeyValue{{"a", 1}, {"b", 2}, {"c", 3}},
+		},
+		{ch, []string{"a", "b"}},
+		{open, []int{1}},
+	}
+	for _, input := range inputs {
+		r, err := value.Collect(input.value)
+		if err != nil {
+			t.Errorf("\nCollect(%T) returned an error: %v", input.value, err)
+		}
+		if !reflect.DeepEqual(r, input.result) {
+			t.Errorf("\nCollect(%T) = %#v, expected %#v", input.value, r, input.result)
+		}
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	var infinite = func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+	if _, err := value.Collect(infinite); err == nil {
+		t.Errorf("\nno error returned by Collect on unbounded iterator")
+	}
+	if _, err := value.Collect(iter.Seq[int](nil)); err == nil {
+		t.Errorf("\nno error returned by Collect on nil iterator")
+	}
+	if _, err := value.Collect(123); err == nil {
+		t.Errorf("\nno error returned by Collect on invalid type")
+	}
+}
+
+func TestCollectChannelOverLimit(t *testing.T) {
+	defer func(n int) { value.MaxCollectedElements = n }(value.MaxCollectedElements)
+	value.MaxCollectedElements = 3
+
+	var ch = make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	r, err := value.Collect(ch)
+	if err == nil {
+		t.Errorf("\nno error returned by Collect on channel over the limit")
+	}
+	if !reflect.DeepEqual(r, []int{0, 1, 2, 3}) || len(ch) != 1 {
+		t.Errorf("\nunexpected elements received by Collect: %v, %v left", r, len(ch))
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	var a = reflect.ValueOf(map[interface{}]int{3: 0, "b": 0, 1: 0})
+	var b = reflect.ValueOf(map[interface{}]int{2: 0, 3: 0, "a": 0})
+
+	var keys []interface{}
+	for _, k := range value.SortedKeys(a, b) {
+		keys = append(keys, k.Interface())
+	}
+	var expected = []interface{}{"a", "b", 1, 2, 3}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("\nunexpected result from SortedKeys()\nexpected: %v\nactual:   %v",
+			expected, keys)
+	}
+}
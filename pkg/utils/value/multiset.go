@@ -0,0 +1,206 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/prettyprint"
+)
+
+// ElementCount captures one distinct element of a collection, along with its
+// number of occurrences.
+type ElementCount struct {
+	Value interface{}
+	Count int
+}
+
+// CompareMultisets compares the elements of two collections independently of
+// order but taking multiplicity into account. It returns the elements found
+// in excess in `lhs` and the elements missing from `lhs`, along with the
+// difference of occurrences, in order of first appearance. Elements are
+// matched with `CompareUnordered()`, which also supports non-comparable
+// elements like slices or structs containing slices.
+func CompareMultisets(lhs, rhs interface{}) (extra, missing []ElementCount, err error) {
+	a, err := collectionElements(lhs)
+	if err != nil {
+		return
+	}
+	b, err := collectionElements(rhs)
+	if err != nil {
+		return
+	}
+
+	var groups []multisetGroup
+	if isHashable(a) && isHashable(b) && sameElementType(a, b) {
+		groups = groupHashable(a, b)
+	} else {
+		groups = groupPairwise(a, b)
+	}
+
+	for _, g := range groups {
+		if g.lhs > g.rhs {
+			extra = append(extra, ElementCount{Value: g.value, Count: g.lhs - g.rhs})
+		} else if g.rhs > g.lhs {
+			missing = append(missing, ElementCount{Value: g.value, Count: g.rhs - g.lhs})
+		}
+	}
+	return
+}
+
+// IndexGroups groups the indexes of equal values, in order of first
+// appearance. Values are matched with `CompareUnordered()`, which also supports
+// non-comparable values like slices or structs containing slices.
+func IndexGroups(values []interface{}) [][]int {
+	var groups [][]int
+	var representatives []interface{}
+	if isHashable(values) && sameElementType(values, nil) {
+		var index = map[interface{}]int{}
+		for i, v := range values {
+			g, ok := index[v]
+			if !ok {
+				g = len(groups)
+				index[v] = g
+				groups = append(groups, nil)
+			}
+			groups[g] = append(groups[g], i)
+		}
+		return groups
+	}
+
+values_loop:
+	for i, v := range values {
+		for g, r := range representatives {
+			if eq, err := CompareUnordered(v, r); eq && err == nil {
+				groups[g] = append(groups[g], i)
+				continue values_loop
+			}
+		}
+		representatives = append(representatives, v)
+		groups = append(groups, []int{i})
+	}
+	return groups
+}
+
+// FormatElementCounts returns a textual representation of a list of elements
+// with their number of occurrences, potentially abbreviated, for the purpose of
+// reporting discrepancies during unit-test.
+func FormatElementCounts(counts []ElementCount) string {
+	var buf strings.Builder
+	for _, c := range counts {
+		if buf.Len() >= 50 {
+			buf.WriteString(", ...")
+			break
+		}
+		if buf.Len() > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(prettyprint.FormatValue(c.Value))
+		if c.Count > 1 {
+			fmt.Fprintf(&buf, " (×%v)", c.Count)
+		}
+	}
+	return buf.String()
+}
+
+// ---------------------------------------------------------------------------
+// Helper functions for multiset comparison
+
+type multisetGroup struct {
+	value    interface{}
+	lhs, rhs int
+}
+
+func collectionElements(value interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(value)
+	k := v.Kind()
+	if !(k == reflect.Slice || k == reflect.Array || k == reflect.String) {
+		return nil, fmt.Errorf(
+			"value of type '%T' is not an indexable collection", value)
+	}
+	var r = make([]interface{}, 0, v.Len())
+	for i, n := 0, v.Len(); i < n; i++ {
+		r = append(r, v.Index(i).Interface())
+	}
+	return r, nil
+}
+
+// isHashable returns true if all the elements are of a basic type that can be
+// used as a map key without risk of panic, and for which equality as map keys
+// matches `CompareUnordered()`. Pointers and channels are excluded as they
+// would be matched by address instead of by pointed value.
+func isHashable(elements []interface{}) bool {
+	for _, e := range elements {
+		if e == nil {
+			return false
+		}
+		switch reflect.TypeOf(e).Kind() {
+		case reflect.Array, reflect.Struct, reflect.Interface, reflect.Map,
+			reflect.Slice, reflect.Func, reflect.Ptr, reflect.Chan,
+			reflect.UnsafePointer:
+			return false
+		}
+	}
+	return true
+}
+
+// sameElementType returns true if all the elements of both collections have
+// the same type, in which case equality as map keys matches
+// `CompareUnordered()`.
+func sameElementType(a, b []interface{}) bool {
+	var t reflect.Type
+	for _, elements := range [][]interface{}{a, b} {
+		for _, e := range elements {
+			if t == nil {
+				t = reflect.TypeOf(e)
+			} else if reflect.TypeOf(e) != t {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func groupHashable(a, b []interface{}) []multisetGroup {
+	var groups []multisetGroup
+	var index = map[interface{}]int{}
+	var add = func(e interface{}) *multisetGroup {
+		i, ok := index[e]
+		if !ok {
+			i = len(groups)
+			index[e] = i
+			groups = append(groups, multisetGroup{value: e})
+		}
+		return &groups[i]
+	}
+	for _, e := range a {
+		add(e).lhs++
+	}
+	for _, e := range b {
+		add(e).rhs++
+	}
+	return groups
+}
+
+func groupPairwise(a, b []interface{}) []multisetGroup {
+	var groups []multisetGroup
+	var add = func(e interface{}) *multisetGroup {
+		for i := range groups {
+			if eq, err := CompareUnordered(e, groups[i].value); eq && err == nil {
+				return &groups[i]
+			}
+		}
+		groups = append(groups, multisetGroup{value: e})
+		return &groups[len(groups)-1]
+	}
+	for _, e := range a {
+		add(e).lhs++
+	}
+	for _, e := range b {
+		add(e).rhs++
+	}
+	return groups
+}
+
+// Helper functions for multiset comparison
+// ---------------------------------------------------------------------------
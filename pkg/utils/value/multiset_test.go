@@ -0,0 +1,89 @@
+package value_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+func TestCompareMultisets(t *testing.T) {
+	type item struct {
+		Tags []string
+	}
+	var inputs = []struct {
+		lhs, rhs       interface{}
+		extra, missing []value.ElementCount
+	}{
+		{[]int{1, 2, 3}, []int{3, 1, 2}, nil, nil},
+		{
+			[]string{"a", "a", "b"}, []string{"a", "b", "b"},
+			[]value.ElementCount{{"a", 1}},
+			[]value.ElementCount{{"b", 1}},
+		},
+		{
+			[]int{1, 1, 1}, []int{},
+			[]value.ElementCount{{1, 3}}, nil,
+		},
+		{[]interface{}{1, int64(2)}, []interface{}{2.0, uint(1)}, nil, nil},
+		{
+			[][]int{{1}, {2}, {1}}, [][]int{{2}, {1}, {3}},
+			[]value.ElementCount{{[]int{1}, 1}},
+			[]value.ElementCount{{[]int{3}, 1}},
+		},
+		{
+			[]item{{[]string{"a"}}, {nil}}, []item{{nil}, {[]string{"a"}}},
+			nil, nil,
+		},
+		{"abca", "aabc", nil, nil},
+		{[]*item{{[]string{"a"}}}, []*item{{[]string{"a"}}}, nil, nil},
+	}
+
+	for _, input := range inputs {
+		extra, missing, err := value.CompareMultisets(input.lhs, input.rhs)
+		if err != nil {
+			t.Errorf("\nunexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(extra, input.extra) || !reflect.DeepEqual(missing, input.missing) {
+			t.Errorf(
+				"\nCompareMultisets(%v, %v)"+
+					"\nextra:   %v, expected %v"+
+					"\nmissing: %v, expected %v",
+				input.lhs, input.rhs, extra, input.extra, missing, input.missing)
+		}
+	}
+}
+
+func TestCompareMultisetsError(t *testing.T) {
+	var _, _, err = value.CompareMultisets(123, []int{})
+	if err == nil {
+		t.Errorf("\nno error returned by CompareMultisets on invalid type")
+	}
+}
+
+func TestFormatElementCounts(t *testing.T) {
+	var s = value.FormatElementCounts([]value.ElementCount{{"a", 2}, {"b", 1}})
+	if s != `"a" (×2), "b"` {
+		t.Errorf("\nunexpected output: |%v|", s)
+	}
+}
+
+func TestIndexGroups(t *testing.T) {
+	var inputs = []struct {
+		values []interface{}
+		groups [][]int
+	}{
+		{[]interface{}{}, nil},
+		{[]interface{}{"a", "b", "a", "c", "b"}, [][]int{{0, 2}, {1, 4}, {3}}},
+		{[]interface{}{1, int64(1), 2.0, uint(2)}, [][]int{{0, 1}, {2, 3}}},
+		{[]interface{}{[]int{1}, []int{2}, []int{1}}, [][]int{{0, 2}, {1}}},
+		{[]interface{}{&[]int{1}, &[]int{2}, &[]int{1}}, [][]int{{0, 2}, {1}}},
+	}
+
+	for _, input := range inputs {
+		groups := value.IndexGroups(input.values)
+		if !reflect.DeepEqual(groups, input.groups) {
+			t.Errorf("\nIndexGroups(%v) = %v, expected %v", input.values, groups, input.groups)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package value
+
+import (
+	"sort"
+)
+
+// EditDistance returns the Levenshtein distance between two strings, i.e. the
+// minimum number of single-rune insertions, deletions or substitutions
+// required to change one into the other.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Nearest returns up to `n` candidates that are the closest to `s` in terms of
+// edit distance, closest first. Candidates that differ from `s` by more than
+// half its length are not considered close and are never returned.
+func Nearest(s string, candidates []string, n int) []string {
+	type candidate struct {
+		s        string
+		distance int
+	}
+	var threshold = max(1, len([]rune(s))/2)
+	var matches []candidate
+	for _, c := range candidates {
+		if d := EditDistance(s, c); d <= threshold {
+			matches = append(matches, candidate{c, d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].s < matches[j].s
+	})
+
+	var r []string
+	for i := 0; i < len(matches) && i < n; i++ {
+		r = append(r, matches[i].s)
+	}
+	return r
+}
@@ -0,0 +1,49 @@
+package value_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/utils/value"
+)
+
+func TestEditDistance(t *testing.T) {
+	var inputs = []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"name", "nmae", 2},
+		{"héllo", "hello", 1},
+	}
+	for _, input := range inputs {
+		if d := value.EditDistance(input.a, input.b); d != input.distance {
+			t.Errorf("\nEditDistance(%q, %q) = %v, expected %v",
+				input.a, input.b, d, input.distance)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	var candidates = []string{"name", "names", "email", "age", "nickname"}
+	var inputs = []struct {
+		s      string
+		n      int
+		result []string
+	}{
+		{"nmae", 3, []string{"name"}},
+		{"namez", 3, []string{"name", "names"}},
+		{"namez", 1, []string{"name"}},
+		{"name", 1, []string{"name"}},
+		{"emial", 3, []string{"email"}},
+		{"zzzzzz", 3, nil},
+	}
+	for _, input := range inputs {
+		if r := value.Nearest(input.s, candidates, input.n); !reflect.DeepEqual(r, input.result) {
+			t.Errorf("\nNearest(%q) = %v, expected %v", input.s, r, input.result)
+		}
+	}
+}
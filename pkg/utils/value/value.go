@@ -6,7 +6,6 @@ package value
 import (
 	"bytes"
 	"fmt"
-	"math"
 	"reflect"
 	"strings"
 	"time"
@@ -26,9 +25,10 @@ import (
 //   and first difference for sequence comparison.
 //
 // MaxAbsoluteDifference
-//   Returns the maximum absolute difference of all the components. Both
-//   values must have the same shape and must be composed of values convertible
-//   to float64 for comparison
+// MaxDifference
+//   Returns the maximum absolute, relative or ULP difference of all the
+//   components. Both values must have the same shape and must be composed of
+//   values convertible to float64 or complex128 for comparison
 
 // ---------------------------------------------------------------------------
 // Helper functions to normalize numeric values into comparable type
@@ -173,8 +173,10 @@ func AsFloat(value interface{}) (float64, bool) {
 
 // CompareOrdered returns the result of the comparison of two values of compatible
 // type that can be ordered, including ints, floats, strings, byte slices,
-// and slices of comparable orderable values. Values of different type or
-// values of the same type that cannot be ordered return an error.
+// times, durations, values with a `Compare()`, `Cmp()` or `Less()` method (e.g.
+// `*big.Int`, `netip.Addr`), and slices of comparable orderable values. Values
+// of different type or values of the same type that cannot be ordered return an
+// error.
 func CompareOrdered(lhs, rhs interface{}) (int, error) {
 
 	if lhsInt, ok := AsInt(lhs); ok {
@@ -209,11 +211,20 @@ func CompareOrdered(lhs, rhs interface{}) (int, error) {
 
 	if lhsTime, ok := lhs.(time.Time); ok {
 		if rhsTime, ok := rhs.(time.Time); ok {
-			dt := lhsTime.Sub(rhsTime)
-			return compareInt(int64(dt), 0), nil
+			return compareTime(lhsTime, rhsTime), nil
 		}
 	}
 
+	if lhsDuration, ok := lhs.(time.Duration); ok {
+		if rhsDuration, ok := rhs.(time.Duration); ok {
+			return compareInt(int64(lhsDuration), int64(rhsDuration)), nil
+		}
+	}
+
+	if order, ok := compareWithMethods(lhs, rhs); ok {
+		return order, nil
+	}
+
 	if isSliceComparable(lhs) && isSliceComparable(rhs) {
 		return compareOrderedSlices(lhs, rhs)
 	}
@@ -256,6 +267,89 @@ func compareFloat(lhs, rhs float64) int {
 	return 1
 }
 
+// compareTime compares two time values as instants, ignoring their monotonic
+// clock reading and location.
+func compareTime(lhs, rhs time.Time) int {
+	if lhs.Equal(rhs) {
+		return 0
+	}
+	if lhs.Before(rhs) {
+		return -1
+	}
+	return 1
+}
+
+// compareWithMethods compares two values through either a `Compare(T) int`
+// method, a `Cmp(T) int` method or a `Less(T) bool` method defined on the
+// left-hand side value, with the right-hand side value as argument. Methods
+// defined on the pointer type are also considered for non-pointer values.
+func compareWithMethods(lhs, rhs interface{}) (int, bool) {
+	a := reflect.ValueOf(lhs)
+	b := reflect.ValueOf(rhs)
+	if !a.IsValid() || !b.IsValid() || isNilPointer(a) || isNilPointer(b) {
+		return 0, false
+	}
+
+	for _, name := range []string{"Compare", "Cmp"} {
+		if m, arg, ok := findCompareMethod(a, b, name, reflect.Int); ok {
+			return compareInt(m.Call([]reflect.Value{arg})[0].Int(), 0), true
+		}
+	}
+
+	if m, arg, ok := findCompareMethod(a, b, "Less", reflect.Bool); ok {
+		if m.Call([]reflect.Value{arg})[0].Bool() {
+			return -1, true
+		}
+		if m, arg, ok := findCompareMethod(b, a, "Less", reflect.Bool); ok {
+			if m.Call([]reflect.Value{arg})[0].Bool() {
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// findCompareMethod looks up a method of `v` with a single argument compatible
+// with `arg`, and a single result of the specified kind. It returns the method
+// and the argument adjusted to the expected type.
+func findCompareMethod(v, arg reflect.Value, name string, result reflect.Kind) (reflect.Value, reflect.Value, bool) {
+	m := v.MethodByName(name)
+	if !m.IsValid() && v.Kind() != reflect.Ptr {
+		m = addressable(v).MethodByName(name)
+	}
+	if !m.IsValid() {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	mt := m.Type()
+	if mt.NumIn() != 1 || mt.IsVariadic() || mt.NumOut() != 1 || mt.Out(0).Kind() != result {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	pt := mt.In(0)
+	switch {
+	case arg.Type().AssignableTo(pt):
+		return m, arg, true
+	case pt.Kind() == reflect.Ptr && arg.Type().AssignableTo(pt.Elem()):
+		return m, addressable(arg), true
+	case arg.Kind() == reflect.Ptr && arg.Elem().Type().AssignableTo(pt):
+		return m, arg.Elem(), true
+	}
+	return reflect.Value{}, reflect.Value{}, false
+}
+
+// addressable returns a pointer to a copy of the value.
+func addressable(v reflect.Value) reflect.Value {
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+func isNilPointer(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
 func isSliceComparable(value interface{}) bool {
 	k := reflect.ValueOf(value).Kind()
 	if k == reflect.Slice || k == reflect.Array || k == reflect.String {
@@ -290,8 +384,9 @@ func compareOrderedSlices(lhs, rhs interface{}) (int, error) {
 // ---------------------------------------------------------------------------
 
 // CompareUnordered returns the result of the equality comparison of two values
-// of compatible type, including ints, floats, strings and slices of
-// comparable values. Other values are compared with reflect.DeepEqual().
+// of compatible type, including ints, floats, strings, times, durations and
+// slices of comparable values. Times are compared as instants, ignoring their
+// monotonic clock reading and location. Other values are compared with reflect.DeepEqual().
 // Values of different tyype that cannot be compared return an error.
 func CompareUnordered(lhs, rhs interface{}) (bool, error) {
 
@@ -319,6 +414,18 @@ func CompareUnordered(lhs, rhs interface{}) (bool, error) {
 		}
 	}
 
+	if lhsTime, ok := lhs.(time.Time); ok {
+		if rhsTime, ok := rhs.(time.Time); ok {
+			return lhsTime.Equal(rhsTime), nil
+		}
+	}
+
+	if lhsDuration, ok := lhs.(time.Duration); ok {
+		if rhsDuration, ok := rhs.(time.Duration); ok {
+			return lhsDuration == rhsDuration, nil
+		}
+	}
+
 	if isSliceComparable(lhs) && isSliceComparable(rhs) {
 		return compareUnorderedSlices(lhs, rhs)
 	}
@@ -362,48 +469,9 @@ func compareUnorderedSlices(lhs, rhs interface{}) (bool, error) {
 
 // MaxAbsoluteDifference returns the maximum absolute difference of all the
 // components. Both values must have the same shape and must be composed of
-// values convertible to float64 for comparison
+// values convertible to float64 for comparison. See `MaxDifference()` for
+// details.
 func MaxAbsoluteDifference(lhs, rhs interface{}) (float64, error) {
-
-	a := reflect.ValueOf(lhs)
-	b := reflect.ValueOf(rhs)
-	ka := a.Kind()
-	kb := b.Kind()
-	if (ka == reflect.Slice || ka == reflect.Array) &&
-		(kb == reflect.Slice || kb == reflect.Array) {
-
-		na := a.Len()
-		nb := b.Len()
-
-		if na != nb {
-			return 0, fmt.Errorf("value length (%v and %v) mismatched", na, nb)
-		}
-
-		max := 0.0
-		for i := 0; i < na; i++ {
-			va := a.Index(i).Interface()
-			vb := b.Index(i).Interface()
-			d, err := MaxAbsoluteDifference(va, vb)
-			if err != nil {
-				return 0, fmt.Errorf(
-					"failed to compare values at index %v, %v", i, err)
-			}
-
-			if d > max {
-				max = d
-			}
-		}
-
-		return max, nil
-	}
-
-	if fa, ok := AsFloat(lhs); ok {
-		if fb, ok := AsFloat(rhs); ok {
-			return math.Abs(fa - fb), nil
-		}
-		return 0, fmt.Errorf(
-			"value of type '%T' cannot be converted to float", rhs)
-	}
-	return 0, fmt.Errorf(
-		"value of type '%T' cannot be converted to float", lhs)
+	d, _, err := MaxDifference(lhs, rhs, AbsoluteDifference)
+	return d, err
 }
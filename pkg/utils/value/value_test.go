@@ -2,6 +2,8 @@ package value_test
 
 import (
 	"math"
+	"math/big"
+	"net/netip"
 	"testing"
 	"time"
 
@@ -213,6 +215,31 @@ func TestComapareOrdered(t *testing.T) {
 		{time.Unix(124, 0), time.Unix(124, 0), 0, false},
 		{time.Unix(124, 0), 124, 0, true},
 		{124, time.Unix(124, 0), 0, true},
+		{time.Unix(124, 0), time.Unix(124, 0).In(time.FixedZone("X", 3600)), 0, false},
+		{time.Now(), time.Now().Add(time.Hour).Round(0), -1, false},
+		{
+			time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+			-1, false,
+		},
+
+		{time.Second, 2 * time.Second, -1, false},
+		{time.Second, time.Second, 0, false},
+		{time.Second, time.Millisecond, 1, false},
+		{time.Second, 1000, 0, true},
+
+		{big.NewInt(1), big.NewInt(2), -1, false},
+		{big.NewInt(2), big.NewInt(2), 0, false},
+		{*big.NewInt(3), big.NewInt(2), 1, false},
+		{big.NewInt(3), *big.NewInt(2), 1, false},
+		{big.NewFloat(1.5), big.NewFloat(2.5), -1, false},
+		{big.NewRat(1, 3), big.NewRat(2, 6), 0, false},
+		{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2"), -1, false},
+		{version{1, 2}, version{1, 10}, -1, false},
+		{version{1, 2}, version{1, 2}, 0, false},
+		{version{2, 0}, version{1, 10}, 1, false},
+		{big.NewInt(1), 2, 0, true},
+		{(*big.Int)(nil), big.NewInt(2), 0, true},
 
 		{
 			[]int{123, 456, 789},
@@ -255,7 +282,16 @@ func TestComapareOrdered(t *testing.T) {
 	}
 }
 
+type version struct {
+	major, minor int
+}
+
+func (v version) Less(other version) bool {
+	return v.major < other.major || v.major == other.major && v.minor < other.minor
+}
+
 func TestComapareUnordered(t *testing.T) {
+	var now = time.Now()
 	var inputs = []struct {
 		lhs, rhs interface{}
 		result   bool
@@ -293,6 +329,12 @@ func TestComapareUnordered(t *testing.T) {
 
 		{123, struct{ a int }{123}, false, true},
 
+		{time.Unix(124, 0), time.Unix(124, 0).In(time.FixedZone("X", 3600)), true, false},
+		{now, now.Round(0), true, false},
+		{time.Unix(124, 0), time.Unix(125, 0), false, false},
+		{time.Second, time.Second, true, false},
+		{time.Second, time.Millisecond, false, false},
+
 		{
 			[]int{123, 456, 789},
 			[]interface{}{123, struct{ a int }{456}, 789},
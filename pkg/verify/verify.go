@@ -3,6 +3,8 @@
 package verify
 
 import (
+	"time"
+
 	"github.com/maargenton/go-testpredicate/pkg/utils/builder"
 	"github.com/maargenton/go-testpredicate/pkg/utils/predicate"
 )
@@ -22,3 +24,35 @@ func That(t predicate.T, v interface{}, ctx ...Context) *builder.Builder {
 	b.Ctx = append(b.Ctx, ctx...)
 	return b
 }
+
+// Eventually captures the test context and a sampling function for the
+// purpose of building a test predicate through call chaining. The predicate is
+// evaluated on a freshly sampled value every `interval` until it passes, or
+// until `timeout` has elapsed. The current test will proceed even if the
+// predicate fails.
+func Eventually(t predicate.T, f func() interface{}, timeout, interval time.Duration, ctx ...Context) *builder.Builder {
+	var b = builder.New(t, nil, false)
+	builder.CaptureCallsite(b, 1)
+	builder.Eventually(b, f, timeout, interval)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return b
+}
+
+// Consistently captures the test context and a sampling function for the
+// purpose of building a test predicate through call chaining. The predicate is
+// evaluated on a freshly sampled value every `interval` and must keep passing
+// until `timeout` has elapsed. The current test will proceed even if the
+// predicate fails.
+func Consistently(t predicate.T, f func() interface{}, timeout, interval time.Duration, ctx ...Context) *builder.Builder {
+	var b = builder.New(t, nil, false)
+	builder.CaptureCallsite(b, 1)
+	builder.Consistently(b, f, timeout, interval)
+	t.Cleanup(func() {
+		builder.VerifyCompletness(b)
+	})
+	b.Ctx = append(b.Ctx, ctx...)
+	return b
+}